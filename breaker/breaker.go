@@ -0,0 +1,249 @@
+// Package breaker implements the circuit breakers of the decorators generated
+// by mockgen with -implementation_type=breaker.
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// Closed lets every call through, counting the consecutive failures.
+	Closed State = iota
+	// Open rejects every call with ErrCircuitOpen until its timeout expires.
+	Open
+	// HalfOpen lets a limited number of probe calls through, closing the
+	// circuit when they succeed and opening it again when one fails.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// ErrCircuitOpen is matched by the errors of the calls rejected by a breaker,
+// as in errors.Is(err, ErrCircuitOpen).
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// OpenError is the error of a call rejected by a breaker.
+type OpenError struct {
+	Name  string // name of the breaker
+	State State  // Open, or HalfOpen when every probe call is in flight
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker %v is %v", e.Name, e.State)
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *OpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Clock tells the time to the breakers.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Defaults of the Settings fields.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = time.Minute
+	DefaultHalfOpenProbes   = 1
+)
+
+// Settings configures a breaker. The zero value is ready to use.
+type Settings struct {
+	// Name identifies the breaker in its errors and state changes.
+	Name string
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. Defaults to DefaultFailureThreshold.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before it is half-open.
+	// Defaults to DefaultOpenTimeout.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of calls let through when the circuit is
+	// half-open, which all have to succeed to close it. Defaults to
+	// DefaultHalfOpenProbes.
+	HalfOpenProbes int
+	// OnStateChange, if not nil, is called when the state of the breaker
+	// changes, after the breaker is unlocked.
+	OnStateChange func(name string, from, to State)
+	// Clock defaults to the system clock.
+	Clock Clock
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // incremented on every state change
+	failures   int    // consecutive failures when closed
+	openedAt   time.Time
+	probes     int // probe calls let through when half-open
+	successes  int // probe calls that succeeded when half-open
+}
+
+// New returns a closed breaker.
+func New(s Settings) *Breaker {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = DefaultFailureThreshold
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = DefaultOpenTimeout
+	}
+	if s.HalfOpenProbes <= 0 {
+		s.HalfOpenProbes = DefaultHalfOpenProbes
+	}
+	if s.Clock == nil {
+		s.Clock = systemClock{}
+	}
+	return &Breaker{settings: s}
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.settings.Name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	state, changed := b.currentState()
+	b.mu.Unlock()
+	b.notify(changed)
+	return state
+}
+
+// transition is a state change to notify.
+type transition struct {
+	from, to State
+}
+
+// currentState returns the state of the breaker, moving it from open to
+// half-open when the open timeout has expired. b.mu must be held.
+func (b *Breaker) currentState() (State, *transition) {
+	if b.state == Open && !b.settings.Clock.Now().Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		return HalfOpen, b.setState(HalfOpen)
+	}
+	return b.state, nil
+}
+
+// setState changes the state of the breaker. b.mu must be held.
+func (b *Breaker) setState(state State) *transition {
+	t := &transition{from: b.state, to: state}
+	b.state = state
+	b.generation++
+	b.failures, b.probes, b.successes = 0, 0, 0
+	if state == Open {
+		b.openedAt = b.settings.Clock.Now()
+	}
+	return t
+}
+
+// notify calls OnStateChange for t, if not nil.
+func (b *Breaker) notify(t *transition) {
+	if t != nil && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.settings.Name, t.from, t.to)
+	}
+}
+
+// Allow reports whether a call may proceed. It returns an *OpenError if not
+// and otherwise a function that has to be called with the outcome of the
+// call once it is over.
+func (b *Breaker) Allow() (done func(failed bool), err error) {
+	b.mu.Lock()
+	state, changed := b.currentState()
+	switch {
+	case state == Open, state == HalfOpen && b.probes >= b.settings.HalfOpenProbes:
+		b.mu.Unlock()
+		b.notify(changed)
+		return nil, &OpenError{Name: b.settings.Name, State: state}
+	case state == HalfOpen:
+		b.probes++
+	}
+	generation := b.generation
+	b.mu.Unlock()
+	b.notify(changed)
+
+	return func(failed bool) {
+		b.done(generation, failed)
+	}, nil
+}
+
+// done records the outcome of a call allowed in the given generation.
+func (b *Breaker) done(generation uint64, failed bool) {
+	b.mu.Lock()
+	if generation != b.generation {
+		// The state changed during the call, which no longer counts.
+		b.mu.Unlock()
+		return
+	}
+	var changed *transition
+	switch b.state {
+	case Closed:
+		if !failed {
+			b.failures = 0
+		} else if b.failures++; b.failures >= b.settings.FailureThreshold {
+			changed = b.setState(Open)
+		}
+	case HalfOpen:
+		if failed {
+			changed = b.setState(Open)
+		} else if b.successes++; b.successes >= b.settings.HalfOpenProbes {
+			changed = b.setState(Closed)
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+}
+
+// Scope is the set of methods whose failures a breaker of a decorator tracks.
+type Scope int
+
+const (
+	// PerMethod tracks the failures of every method separately.
+	PerMethod Scope = iota
+	// PerInterface tracks the failures of all the methods together.
+	PerInterface
+)
+
+// NewMethods returns the breakers of the given methods of the interface
+// intf, by method name, created with s according to scope. The breakers are
+// named after the interface, followed by the method if per method.
+func NewMethods(s Settings, scope Scope, intf string, methods ...string) map[string]*Breaker {
+	breakers := make(map[string]*Breaker, len(methods))
+	var shared *Breaker
+	for _, m := range methods {
+		if scope == PerInterface {
+			if shared == nil {
+				s.Name = intf
+				shared = New(s)
+			}
+			breakers[m] = shared
+			continue
+		}
+		s.Name = intf + "." + m
+		breakers[m] = New(s)
+	}
+	return breakers
+}
@@ -0,0 +1,130 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// call makes a call through b failing as told and returns the error of Allow.
+func call(b *Breaker, failed bool) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	done(failed)
+	return nil
+}
+
+func TestBreaker(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var changes []string
+	b := New(Settings{
+		Name:             "store",
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		HalfOpenProbes:   2,
+		Clock:            clock,
+		OnStateChange: func(name string, from, to State) {
+			changes = append(changes, fmt.Sprintf("%v: %v -> %v", name, from, to))
+		},
+	})
+
+	// A success resets the consecutive failures.
+	for _, failed := range []bool{true, false, true} {
+		if err := call(b, failed); err != nil {
+			t.Fatalf("closed breaker rejected a call: %v", err)
+		}
+	}
+	if got := b.State(); got != Closed {
+		t.Fatalf("State() = %v, want closed", got)
+	}
+
+	_ = call(b, true)
+	if got := b.State(); got != Open {
+		t.Fatalf("State() = %v after 2 failures, want open", got)
+	}
+	err := call(b, false)
+	var openErr *OpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Name != "store" {
+		t.Fatalf("open breaker call = %v, want an *OpenError of store", err)
+	}
+
+	// Half-open, a failed probe opens the circuit again.
+	clock.advance(time.Second)
+	if err := call(b, true); err != nil {
+		t.Fatalf("half-open breaker rejected a probe: %v", err)
+	}
+	if got := b.State(); got != Open {
+		t.Fatalf("State() = %v after a failed probe, want open", got)
+	}
+
+	// Half-open, at most 2 probes are in flight, and both have to succeed.
+	clock.advance(time.Second)
+	done1, err1 := b.Allow()
+	done2, err2 := b.Allow()
+	if err1 != nil || err2 != nil {
+		t.Fatalf("half-open breaker rejected a probe: %v, %v", err1, err2)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("third probe = %v, want %v", err, ErrCircuitOpen)
+	}
+	done1(false)
+	if got := b.State(); got != HalfOpen {
+		t.Fatalf("State() = %v after a successful probe, want half-open", got)
+	}
+	done2(false)
+	if got := b.State(); got != Closed {
+		t.Fatalf("State() = %v after the successful probes, want closed", got)
+	}
+
+	want := []string{
+		"store: closed -> open",
+		"store: open -> half-open",
+		"store: half-open -> open",
+		"store: open -> half-open",
+		"store: half-open -> closed",
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("state changes = %q, want %q", changes, want)
+	}
+}
+
+func TestBreaker_StaleOutcome(t *testing.T) {
+	b := New(Settings{FailureThreshold: 1})
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = call(b, true)
+	if got := b.State(); got != Open {
+		t.Fatalf("State() = %v, want open", got)
+	}
+	// The outcome of a call started before the circuit opened is ignored.
+	done(false)
+	if got := b.State(); got != Open {
+		t.Errorf("State() = %v after a stale success, want open", got)
+	}
+}
+
+func TestNewMethods(t *testing.T) {
+	perMethod := NewMethods(Settings{}, PerMethod, "Store", "Get", "Put")
+	if perMethod["Get"] == perMethod["Put"] || perMethod["Get"].Name() != "Store.Get" {
+		t.Errorf("per method breakers are shared or misnamed: %v, %v", perMethod["Get"].Name(), perMethod["Put"].Name())
+	}
+	perInterface := NewMethods(Settings{}, PerInterface, "Store", "Get", "Put")
+	if perInterface["Get"] != perInterface["Put"] || perInterface["Get"].Name() != "Store" {
+		t.Errorf("per interface breakers are not shared or misnamed: %v, %v", perInterface["Get"].Name(), perInterface["Put"].Name())
+	}
+}
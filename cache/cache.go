@@ -0,0 +1,152 @@
+// Package cache implements the caches of the decorators generated by mockgen
+// with -implementation_type=cache.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache stores the results of the calls of a method by key. The keys are
+// comparable values. Implementations have to be safe for concurrent use.
+type Cache interface {
+	// Get returns the value of key, if present.
+	Get(key any) (value any, ok bool)
+	// Add sets the value of key.
+	Add(key, value any)
+	// Remove removes key, if present.
+	Remove(key any)
+	// Purge removes every key.
+	Purge()
+}
+
+// Clock tells the time to the caches.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Options configures an LRU. The zero value sets no limit.
+type Options struct {
+	// TTL is how long the values are kept after they are added, if positive.
+	TTL time.Duration
+	// MaxSize is the maximum number of keys, if positive. The least recently
+	// used keys are evicted first.
+	MaxSize int
+	// Clock defaults to the system clock.
+	Clock Clock
+}
+
+// LRU is a Cache evicting its least recently used keys and the keys whose
+// TTL has expired.
+type LRU struct {
+	options Options
+
+	mu    sync.Mutex
+	order *list.List // of *entry, most recently used first
+	items map[any]*list.Element
+}
+
+type entry struct {
+	key, value any
+	expires    time.Time // zero if the value does not expire
+}
+
+var _ Cache = (*LRU)(nil)
+
+// New returns an empty LRU.
+func New(o Options) *LRU {
+	if o.Clock == nil {
+		o.Clock = systemClock{}
+	}
+	return &LRU{
+		options: o,
+		order:   list.New(),
+		items:   make(map[any]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRU) Get(key any) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !e.expires.IsZero() && !c.options.Clock.Now().Before(e.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Add implements Cache.
+func (c *LRU) Add(key, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.options.TTL > 0 {
+		expires = c.options.Clock.Now().Add(c.options.TTL)
+	}
+	if el, ok := c.items[key]; ok {
+		el.Value = &entry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, expires: expires})
+	if c.options.MaxSize > 0 && c.order.Len() > c.options.MaxSize {
+		c.remove(c.order.Back())
+	}
+}
+
+// Remove implements Cache.
+func (c *LRU) Remove(key any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Purge implements Cache.
+func (c *LRU) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items = make(map[any]*list.Element)
+}
+
+// Len returns the number of keys, including those whose TTL has expired but
+// that were not evicted yet.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove removes el. c.mu must be held.
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
+
+// NewMethods returns the caches of the given methods, by method name. They
+// are created by newCache if not nil, and otherwise are LRUs created with o.
+func NewMethods(o Options, newCache func(method string) Cache, methods ...string) map[string]Cache {
+	caches := make(map[string]Cache, len(methods))
+	for _, m := range methods {
+		if newCache != nil {
+			caches[m] = newCache(m)
+		} else {
+			caches[m] = New(o)
+		}
+	}
+	return caches
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+type key struct {
+	a string
+	b int
+}
+
+func TestLRU_TTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := New(Options{TTL: time.Second, Clock: clock})
+
+	c.Add(key{"a", 1}, "v")
+	if v, ok := c.Get(key{"a", 1}); !ok || v != "v" {
+		t.Fatalf("Get() = %v, %v, want v, true", v, ok)
+	}
+	if _, ok := c.Get(key{"a", 2}); ok {
+		t.Fatal("Get() found a missing key")
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if _, ok := c.Get(key{"a", 1}); ok {
+		t.Error("Get() found an expired key")
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() = %d after an expired Get, want 0", n)
+	}
+}
+
+func TestLRU_MaxSize(t *testing.T) {
+	c := New(Options{MaxSize: 2})
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Get("a")
+	c.Add("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found the least recently used key")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("Get(%v) did not find a recently used key", k)
+		}
+	}
+}
+
+func TestLRU_RemovePurge(t *testing.T) {
+	c := New(Options{})
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) found a removed key")
+	}
+	c.Purge()
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() = %d after Purge, want 0", n)
+	}
+}
+
+func TestNewMethods(t *testing.T) {
+	caches := NewMethods(Options{MaxSize: 1}, nil, "Get", "List")
+	if caches["Get"] == caches["List"] {
+		t.Error("NewMethods() shares a cache between methods")
+	}
+
+	shared := New(Options{})
+	caches = NewMethods(Options{}, func(string) Cache { return shared }, "Get")
+	if caches["Get"] != Cache(shared) {
+		t.Error("NewMethods() did not create the caches with newCache")
+	}
+}
@@ -0,0 +1,218 @@
+// Package chaos implements the fault injection of the decorators generated by
+// mockgen with -implementation_type=chaos.
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInjected is matched by the injected errors, as in
+// errors.Is(err, ErrInjected).
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Error is an injected error.
+type Error struct {
+	Method  string // Interface.Method name
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("chaos: %v: %v", e.Method, e.Message)
+}
+
+// Is reports whether target is ErrInjected.
+func (e *Error) Is(target error) bool {
+	return target == ErrInjected
+}
+
+// Rule injects faults into the calls of the methods it matches.
+type Rule struct {
+	// Method selects the methods, as Interface.Method, Interface, or * or
+	// empty for every method.
+	Method string `json:"method"`
+	// Probability is the probability of the faults of each call, between 0
+	// and 1: 0 never injects them. Defaults to 1 when nil.
+	Probability *float64 `json:"probability"`
+	// After is the number of calls of each method without faults, before the
+	// rule applies.
+	After int `json:"after"`
+	// Times is the number of calls with faults, after which the rule no
+	// longer applies. Defaults to no limit when 0.
+	Times int `json:"times"`
+
+	// Latency delays the calls. In JSON, it is a duration such as "100ms".
+	Latency time.Duration `json:"latency"`
+	// Error fails the calls with an *Error with this message.
+	Error string `json:"error"`
+	// Err fails the calls with this error, in place of Error.
+	Err error `json:"-"`
+	// Panic makes the calls panic with this value.
+	Panic string `json:"panic"`
+}
+
+// UnmarshalJSON decodes a rule, whose latency is a duration string.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	type rule Rule
+	aux := struct {
+		*rule
+		Latency string `json:"latency"`
+	}{rule: (*rule)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Latency != "" {
+		d, err := time.ParseDuration(aux.Latency)
+		if err != nil {
+			return fmt.Errorf("chaos: rule latency: %w", err)
+		}
+		r.Latency = d
+	}
+	return nil
+}
+
+// Probability returns a pointer to p, the probability of a Rule.
+func Probability(p float64) *float64 {
+	return &p
+}
+
+func (r *Rule) validate() error {
+	if p := r.Probability; p != nil && (*p < 0 || *p > 1) {
+		return fmt.Errorf("chaos: rule of %q: probability %v is not between 0 and 1", r.Method, *p)
+	}
+	if r.After < 0 || r.Times < 0 || r.Latency < 0 {
+		return fmt.Errorf("chaos: rule of %q: negative after, times or latency", r.Method)
+	}
+	return nil
+}
+
+// matches reports whether r selects the method, an Interface.Method name.
+func (r *Rule) matches(method string) bool {
+	if r.Method == "" || r.Method == "*" || r.Method == method {
+		return true
+	}
+	intf, _, _ := strings.Cut(method, ".")
+	return r.Method == intf
+}
+
+// injects reports whether r injects faults into the methods that can or
+// cannot fail.
+func (r *Rule) injects(fails bool) bool {
+	return r.Latency > 0 || r.Panic != "" || fails && (r.Error != "" || r.Err != nil)
+}
+
+// Load reads the rules from a JSON array.
+func Load(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("chaos: %w", err)
+	}
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// LoadFile reads the rules from a JSON file, holding an array.
+func LoadFile(path string) ([]Rule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Injector injects the faults of its rules into the calls. The first rule
+// that applies to a call injects its faults, in order its latency, its panic
+// and its error.
+type Injector struct {
+	mu      sync.Mutex
+	rand    *rand.Rand
+	rules   []Rule
+	applied []int          // calls with faults, by rule
+	calls   map[string]int // calls, by method
+}
+
+// New returns an Injector with rules, drawing the probabilities of the
+// faults from seed. Injectors with the same seed and rules inject the same
+// faults into the same calls.
+func New(seed int64, rules ...Rule) *Injector {
+	i := &Injector{rand: rand.New(rand.NewSource(seed)), calls: make(map[string]int)}
+	i.SetRules(rules...)
+	return i
+}
+
+// SetRules replaces the rules of i. The counts of the calls are kept, but
+// not the ones of the calls with faults.
+func (i *Injector) SetRules(rules ...Rule) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = append([]Rule(nil), rules...)
+	i.applied = make([]int, len(rules))
+}
+
+// rule returns the rule applying to a call of method, or nil.
+func (i *Injector) rule(method string, fails bool) *Rule {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls[method]++
+	n := i.calls[method]
+	for j := range i.rules {
+		r := &i.rules[j]
+		if !r.matches(method) || !r.injects(fails) || n <= r.After || r.Times > 0 && i.applied[j] >= r.Times {
+			continue
+		}
+		if p := r.Probability; p != nil && *p < 1 && i.rand.Float64() >= *p {
+			continue
+		}
+		i.applied[j]++
+		return r
+	}
+	return nil
+}
+
+// Inject injects the faults of the rule applying to a call of method, an
+// Interface.Method name, if any. fails reports whether the method can fail,
+// and so whether the rules injecting errors apply to it. The latencies are
+// cut short when ctx is done, whose error is then returned.
+func (i *Injector) Inject(ctx context.Context, method string, fails bool) error {
+	r := i.rule(method, fails)
+	if r == nil {
+		return nil
+	}
+	if r.Latency > 0 {
+		t := time.NewTimer(r.Latency)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			if fails {
+				return ctx.Err()
+			}
+		}
+	}
+	if r.Panic != "" {
+		panic(r.Panic)
+	}
+	if !fails {
+		return nil
+	}
+	if r.Err != nil {
+		return r.Err
+	}
+	if r.Error != "" {
+		return &Error{Method: method, Message: r.Error}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInjector_Inject(t *testing.T) {
+	errDown := errors.New("down")
+	i := New(1,
+		Rule{Method: "Store.Get", After: 1, Times: 2, Error: "unavailable"},
+		Rule{Method: "Store", Err: errDown},
+	)
+
+	ctx := context.Background()
+	var got []error
+	for n := 0; n < 4; n++ {
+		got = append(got, i.Inject(ctx, "Store.Get", true))
+	}
+	if got[0] != errDown || !errors.Is(got[1], ErrInjected) || !errors.Is(got[2], ErrInjected) || got[3] != errDown {
+		t.Errorf("Inject(Store.Get) = %v, want down, 2 injected errors and down", got)
+	}
+	if err := got[1].Error(); err != "chaos: Store.Get: unavailable" {
+		t.Errorf("Error() = %q", err)
+	}
+	// The errors are not injected into the methods that cannot fail.
+	if err := i.Inject(ctx, "Store.Keys", false); err != nil {
+		t.Errorf("Inject(Store.Keys) = %v, want nil", err)
+	}
+	if err := i.Inject(ctx, "Config.Get", true); err != nil {
+		t.Errorf("Inject(Config.Get) = %v, want nil", err)
+	}
+}
+
+func TestInjector_Probability(t *testing.T) {
+	faults := func(seed int64) []bool {
+		i := New(seed, Rule{Probability: Probability(0.5), Error: "unavailable"})
+		var fs []bool
+		for n := 0; n < 100; n++ {
+			fs = append(fs, i.Inject(context.Background(), "Store.Get", true) != nil)
+		}
+		return fs
+	}
+	a, b := faults(42), faults(42)
+	count := 0
+	for n := range a {
+		if a[n] != b[n] {
+			t.Fatalf("call %d has a fault with one injector but not with the other of the same seed", n)
+		}
+		if a[n] {
+			count++
+		}
+	}
+	if count < 25 || count > 75 {
+		t.Errorf("%d calls out of 100 have a fault, want about 50", count)
+	}
+
+	// A probability of 0 turns the rule off.
+	i := New(1, Rule{Probability: Probability(0), Error: "unavailable"})
+	for n := 0; n < 100; n++ {
+		if err := i.Inject(context.Background(), "Store.Get", true); err != nil {
+			t.Fatalf("Inject() = %v with a probability of 0, want nil", err)
+		}
+	}
+}
+
+func TestInjector_LatencyPanic(t *testing.T) {
+	i := New(1, Rule{Method: "Store.Get", Latency: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := i.Inject(ctx, "Store.Get", true); err != context.DeadlineExceeded {
+		t.Errorf("Inject() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	i.SetRules(Rule{Panic: "boom"})
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Inject() panicked with %v, want boom", r)
+		}
+	}()
+	_ = i.Inject(context.Background(), "Store.Keys", false)
+}
+
+func TestLoad(t *testing.T) {
+	rules, err := Load(strings.NewReader(`[
+		{"method": "Store.Get", "probability": 0.1, "latency": "250ms"},
+		{"method": "Payments", "after": 3, "times": 1, "error": "declined"},
+		{"method": "Store.Put", "probability": 0, "error": "off"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 3 || rules[0].Latency != 250*time.Millisecond || rules[0].Probability == nil || *rules[0].Probability != 0.1 || rules[1].Error != "declined" || rules[1].After != 3 {
+		t.Errorf("Load() = %+v", rules)
+	}
+	// A missing probability means always, and 0 never.
+	if rules[1].Probability != nil || rules[2].Probability == nil || *rules[2].Probability != 0 {
+		t.Errorf("Load() = %+v, want the probabilities nil and 0", rules)
+	}
+
+	for _, bad := range []string{
+		`[{"probability": 2}]`,
+		`[{"latency": "soon"}]`,
+		`{"method": "Store.Get"}`,
+	} {
+		if _, err := Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("Load(%s) = nil, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package fallback implements the failover of the decorators generated by
+// mockgen with -implementation_type=fallback.
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Backend is an implementation wrapped by a fallback decorator.
+type Backend int
+
+const (
+	// Primary is called first.
+	Primary Backend = iota
+	// Secondary is called when the primary fails with an accepted error.
+	Secondary
+)
+
+func (b Backend) String() string {
+	switch b {
+	case Primary:
+		return "primary"
+	case Secondary:
+		return "secondary"
+	}
+	return fmt.Sprintf("Backend(%d)", int(b))
+}
+
+// Accept reports whether the secondary is called when the primary failed
+// with err. It accepts every error but the ones of a context that is done,
+// which the secondary would fail with too.
+func Accept(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,35 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBackend_String(t *testing.T) {
+	for b, want := range map[Backend]string{
+		Primary:    "primary",
+		Secondary:  "secondary",
+		Backend(7): "Backend(7)",
+	} {
+		if got := b.String(); got != want {
+			t.Errorf("Backend(%d).String() = %q, want %q", int(b), got, want)
+		}
+	}
+}
+
+func TestAccept(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("unavailable"), true},
+		{context.Canceled, false},
+		{fmt.Errorf("get: %w", context.DeadlineExceeded), false},
+	} {
+		if got := Accept(tc.err); got != tc.want {
+			t.Errorf("Accept(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
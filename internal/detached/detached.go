@@ -0,0 +1,27 @@
+// Package detached implements the contexts that the decorators pass to the
+// calls outliving their callers.
+package detached
+
+import (
+	"context"
+	"time"
+)
+
+// ctx is a context carrying the values of its parent, but never done.
+type ctx struct {
+	parent context.Context
+}
+
+// WithoutCancel returns a context carrying the values of parent, which is
+// never done, as context.WithoutCancel of Go 1.21.
+func WithoutCancel(parent context.Context) context.Context {
+	return ctx{parent: parent}
+}
+
+func (ctx) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (ctx) Done() <-chan struct{} { return nil }
+
+func (ctx) Err() error { return nil }
+
+func (c ctx) Value(key any) any { return c.parent.Value(key) }
@@ -0,0 +1,24 @@
+package detached
+
+import (
+	"context"
+	"testing"
+)
+
+type valueKey struct{}
+
+func TestWithoutCancel(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), valueKey{}, "v"), 0)
+	defer cancel()
+	<-parent.Done()
+	ctx := WithoutCancel(parent)
+	if ctx.Err() != nil || ctx.Done() != nil {
+		t.Error("WithoutCancel() is done with its parent")
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("WithoutCancel() has the deadline of its parent")
+	}
+	if v := ctx.Value(valueKey{}); v != "v" {
+		t.Errorf("Value() = %v, want v", v)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+// This file contains the selection of the method arguments and results that
+// decorators record, e.g. as span attributes.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// attributeKind classifies how a value of a type is recorded.
+type attributeKind int
+
+const (
+	attrNone         attributeKind = iota // not recorded
+	attrString                            // string
+	attrBool                              // bool
+	attrInt                               // int
+	attrInt64                             // other integers, converted to int64
+	attrFloat64                           // floating point numbers, converted to float64
+	attrDuration                          // time.Duration, recorded as its String()
+	attrExtractor                         // named types, recorded through an extractor method
+	attrExtractorPtr                      // pointers to named types, as attrExtractor unless nil
+)
+
+// attributeKindOf returns how values of type t are recorded.
+func attributeKindOf(t model.Type) attributeKind {
+	switch t := t.(type) {
+	case model.PredeclaredType:
+		switch t {
+		case "string":
+			return attrString
+		case "bool":
+			return attrBool
+		case "int":
+			return attrInt
+		case "int8", "int16", "int32", "int64", "rune", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "uintptr":
+			return attrInt64
+		case "float32", "float64":
+			return attrFloat64
+		}
+	case *model.NamedType:
+		if t.Package == "time" && t.Type == "Duration" {
+			return attrDuration
+		}
+		if t.Package != "" && t.Package != "context" {
+			return attrExtractor
+		}
+	case *model.PointerType:
+		if nt, ok := t.Type.(*model.NamedType); ok && nt.Package != "" {
+			return attrExtractorPtr
+		}
+	}
+	return attrNone
+}
+
+// attributeOptions selects the arguments and results recorded by decorators.
+type attributeOptions struct {
+	args    map[string]bool // selectors of the methods whose arguments are recorded
+	results map[string]bool // selectors of the methods whose results are recorded
+	exclude map[string]bool // names of parameters that are never recorded
+}
+
+// parseSelectors parses a comma-separated list of interface names,
+// Interface.Method pairs or "*" for every method of every interface.
+func parseSelectors(s string) map[string]bool {
+	sel := make(map[string]bool)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			sel[v] = true
+		}
+	}
+	return sel
+}
+
+// selects reports whether sel contains the method of the interface.
+func selects(sel map[string]bool, intf, method string) bool {
+	return sel["*"] || sel[intf] || sel[intf+"."+method]
+}
+
+// recordedParam is a method argument or result that is recorded.
+type recordedParam struct {
+	key  string // attribute key
+	expr string // Go expression holding the value
+	kind attributeKind
+}
+
+// recordedArgs returns the arguments of m that are recorded, given their
+// generated names. They are keyed and excluded by their declared names,
+// which the generated names may rename.
+func (o attributeOptions) recordedArgs(intf string, m *model.Method, argNames []string) []recordedParam {
+	if !selects(o.args, intf, m.Name) {
+		return nil
+	}
+	var rps []recordedParam
+	for i, p := range m.In {
+		kind := attributeKindOf(p.Type)
+		key := p.Name
+		if key == "" || key == "_" {
+			key = argNames[i]
+		}
+		if kind == attrNone || o.exclude[key] {
+			continue
+		}
+		rps = append(rps, recordedParam{key: key, expr: argNames[i], kind: kind})
+	}
+	return rps
+}
+
+// recordedResults returns the results of m that are recorded, given the
+// identifiers holding them.
+func (o attributeOptions) recordedResults(intf string, m *model.Method, retNames []string) []recordedParam {
+	if !selects(o.results, intf, m.Name) {
+		return nil
+	}
+	var rps []recordedParam
+	for i, p := range m.Out {
+		kind := attributeKindOf(p.Type)
+		key := p.Name
+		if key == "" || key == "_" {
+			key = fmt.Sprintf("result.%d", i)
+		}
+		if kind == attrNone || o.exclude[key] {
+			continue
+		}
+		rps = append(rps, recordedParam{key: key, expr: retNames[i], kind: kind})
+	}
+	return rps
+}
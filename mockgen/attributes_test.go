@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestRecordedArgs(t *testing.T) {
+	g := generator{decorates: true, packageMap: map[string]string{"time": "time"}}
+	o := attributeOptions{args: parseSelectors("*"), exclude: parseSelectors("time")}
+	m := &model.Method{
+		Name: "Schedule",
+		In: []*model.Parameter{
+			{Name: "key", Type: model.PredeclaredType("string")},
+			{Name: "time", Type: model.PredeclaredType("string")},
+			{Name: "_", Type: model.PredeclaredType("string")},
+		},
+	}
+
+	argNames := g.getArgNames(m, true)
+	if argNames[1] != "time_" {
+		t.Fatalf("getArgNames() = %v, want time renamed to time_", argNames)
+	}
+	// The exclusions match the declared names, not the generated ones.
+	got := o.recordedArgs("Scheduler", m, argNames)
+	kind := attributeKindOf(model.PredeclaredType("string"))
+	want := []recordedParam{
+		{key: "key", expr: "key", kind: kind},
+		{key: "arg2", expr: "arg2", kind: kind},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recordedArgs() = %+v, want %+v", got, want)
+	}
+}
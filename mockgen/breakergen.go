@@ -0,0 +1,180 @@
+package main
+
+// This file contains the circuit breaker decorator, which rejects the calls
+// of the failing methods with the state machine of the breaker package.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const breakerImportPath = "github.com/pableeee/implgen/breaker"
+
+// breakerImports are the packages referenced by the circuit breaker decorator.
+var breakerImports = map[string]string{
+	breakerImportPath: "",
+}
+
+// breakerScopes maps the scopes of the breakers to the constants of the
+// breaker package.
+var breakerScopes = map[string]string{
+	"method":    "PerMethod",
+	"interface": "PerInterface",
+}
+
+// breakerOptions configures the circuit breaker decorator.
+type breakerOptions struct {
+	scope string // a key of breakerScopes; method if empty
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) breakingName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Breaking" + typeName + "Impl"
+}
+
+func generateBreakingInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.breakingName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	breakerType := "*" + g.qualify(breakerImportPath, "Breaker")
+	settingsType := g.qualify(breakerImportPath, "Settings")
+	scopeType := g.qualify(breakerImportPath, "Scope")
+
+	sort.Sort(byMethodName(intf.Methods))
+	var broken []string
+	for _, m := range intf.Methods {
+		// The decorator has its own Breaker method, which must not collide
+		// with the ones of the interface.
+		if m.Name == "Breaker" {
+			return fmt.Errorf("%v: the Breaker accessor collides with the method Breaker", mockType)
+		}
+		// Methods that cannot return the rejections have no breaker.
+		if returnsError(m) {
+			broken = append(broken, fmt.Sprintf("%q", m.Name))
+		} else if errorResultOf(m).index >= 0 {
+			log.Printf("Warning: %v.%v has no result of type error and has no circuit breaker", intf.Name, m.Name)
+		}
+	}
+
+	g.p("")
+	g.p("// %v is a circuit breaker decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate  %v", intfType)
+	g.p("breakers  map[string]%v", breakerType)
+	g.p("isFailure func(error) bool")
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	scope := g.breaker.scope
+	if scope == "" {
+		scope = "method"
+	}
+	opts := []decoratorOption{
+		{
+			name:  "Scope",
+			param: "scope",
+			field: "scope",
+			typ:   scopeType,
+			def:   g.qualify(breakerImportPath, breakerScopes[scope]),
+			doc: "sets whether a " + mockType + " tracks the failures\n" +
+				"of its methods with a breaker per method or with a single one.",
+		},
+		errorClassifierOption(mockType),
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new circuit breaker decorator instance, whose", mockType)
+	g.p("// breakers are created with settings.")
+	g.p("func New%v%v(ctrl %v, settings %v, opts ...%v) *%v%v {", mockType, longTp, intfType, settingsType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate:  ctrl,")
+	g.p("breakers:  %v(settings, o.scope, %q%v),", g.qualify(breakerImportPath, "NewMethods"), intf.Name, strings.Join(append([]string{""}, broken...), ", "))
+	g.p("isFailure: o.isFailure,")
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+	g.p("")
+
+	g.p("// Breaker returns the circuit breaker of the method, or nil if it has none.")
+	g.p("func (t *%v%v) Breaker(method string) %v {", mockType, shortTp, breakerType)
+	g.in()
+	g.p("return t.breakers[method]")
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateBreakingMethod(g, mockType, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateBreakingMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v circuit breaking base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if !returnsError(m) {
+		if len(rets) == 0 {
+			g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+		return
+	}
+
+	idDone := ia.allocateIdentifier("done")
+	idErr := ia.allocateIdentifier("err")
+	idFailed := ia.allocateIdentifier("failed")
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+	er := errorResultOf(m)
+
+	g.p("%v, %v := %v.breakers[%q].Allow()", idDone, idErr, idRecv, m.Name)
+	g.p("if %v != nil {", idErr)
+	g.in()
+	g.generateErrorReturn(m, rets, returns, idErr)
+	g.out()
+	g.p("}")
+	// A panic of the delegate counts as a failure.
+	g.p("%v := true", idFailed)
+	g.p("defer func() {")
+	g.in()
+	g.p("%v(%v)", idDone, idFailed)
+	g.out()
+	g.p("}()")
+	g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+	g.p("%v = %v != nil && %v.isFailure(%v)", idFailed, returns[er.index], idRecv, returns[er.index])
+	g.p("return %v", strings.Join(returns, ", "))
+	g.out()
+	g.p("}")
+}
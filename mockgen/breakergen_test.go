@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateBreakingInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{breakerImportPath: "breaker"},
+		breaker:    breakerOptions{scope: "interface"},
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{{Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Flush",
+		Out:  []*model.Parameter{{Type: &model.PointerType{Type: &model.NamedType{Package: "somepackage", Type: "FlushError"}}}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateBreakingInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewBreakingStoreImpl(ctrl Store, settings breaker.Settings, opts ...BreakingStoreImplOption) *BreakingStoreImpl {",
+		"o := breakingStoreImplOptions{scope: breaker.PerInterface, isFailure: func(error) bool { return true }}",
+		`breakers:  breaker.NewMethods(settings, o.scope, "Store", "Get"),`,
+		`done, err := t.breakers["Get"].Allow()`,
+		"return ret, err",
+		"failed = ret_2 != nil && t.isFailure(ret_2)",
+		"return t.delegate.Flush()",
+		"t.delegate.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateBreakingInterface_Collision(t *testing.T) {
+	g := generator{}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{Name: "Breaker"})
+	want := "BreakingStoreImpl: the Breaker accessor collides with the method Breaker"
+	if err := generateBreakingInterface(&g, intf, "somepackage"); err == nil || err.Error() != want {
+		t.Errorf("generateBreakingInterface() = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+// This file contains the cache decorator, which memoizes the results of the
+// selected methods with the cache package.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const cacheImportPath = "github.com/pableeee/implgen/cache"
+
+// cacheImports are the packages referenced by the cache decorator.
+var cacheImports = map[string]string{
+	cacheImportPath: "",
+}
+
+// Directives of the cache decorator.
+const (
+	cacheDirective       = "cache"       // the results of the method are cached
+	invalidatesDirective = "invalidates" // the method purges the caches of the methods given as arguments
+)
+
+// cacheOptions configures the cache decorator.
+type cacheOptions struct {
+	methods    map[string]bool     // selectors of the methods whose results are cached
+	invalidate map[string][]string // cached methods purged by the Interface.Method keys
+}
+
+// parseInvalidations parses a comma-separated list of Interface.Method=Cached
+// pairs, where the calls of Interface.Method purge the cache of Cached.
+func parseInvalidations(s string) (map[string][]string, error) {
+	inv := make(map[string][]string)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		method, cached, ok := strings.Cut(v, "=")
+		if !ok || !strings.Contains(method, ".") || cached == "" {
+			return nil, fmt.Errorf("bad invalidation %q, want Interface.Method=Cached", v)
+		}
+		inv[method] = append(inv[method], cached)
+	}
+	return inv, nil
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) cachedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Cached" + typeName + "Impl"
+}
+
+func generateCachedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.cachedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	cacheType := g.qualify(cacheImportPath, "Cache")
+
+	sort.Sort(byMethodName(intf.Methods))
+	cached := make(map[string]*callKey)
+	var names []string
+	for _, m := range intf.Methods {
+		if !selects(g.cache.methods, intf.Name, m.Name) && !hasDirective(m, cacheDirective) {
+			continue
+		}
+		if len(m.Out) == 0 {
+			return fmt.Errorf("%v.%v has no results to cache", intf.Name, m.Name)
+		}
+		ck, err := g.newCallKey(intf, m, unexported(mockType))
+		if err != nil {
+			return err
+		}
+		cached[m.Name] = ck
+		names = append(names, fmt.Sprintf("%q", m.Name))
+	}
+	invalidations := make(map[string][]string)
+	for _, m := range intf.Methods {
+		targets, _ := directiveArgs(m, invalidatesDirective)
+		targets = append(g.cache.invalidate[intf.Name+"."+m.Name], targets...)
+		for _, target := range targets {
+			if cached[target] == nil {
+				return fmt.Errorf("%v.%v invalidates %v, whose results are not cached", intf.Name, m.Name, target)
+			}
+		}
+		invalidations[m.Name] = targets
+	}
+
+	g.p("")
+	g.p("// %v is a caching decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("caches   map[string]%v", cacheType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	for _, m := range intf.Methods {
+		if cm := cached[m.Name]; cm != nil {
+			g.generateCallKeyTypes(m, cm, longTp, outputPackagePath)
+		}
+	}
+
+	opts := []decoratorOption{
+		{
+			name:  "Caches",
+			param: "newCache",
+			field: "newCache",
+			typ:   fmt.Sprintf("func(method string) %v", cacheType),
+			doc: "sets the function creating the cache of each\n" +
+				"method of a " + mockType + " whose results are cached, in place of\n" +
+				"the LRUs created with the options passed to its constructor.",
+		},
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new caching decorator instance, whose caches are", mockType)
+	g.p("// created with options.")
+	g.p("func New%v%v(ctrl %v, options %v, opts ...%v) *%v%v {", mockType, longTp, intfType, g.qualify(cacheImportPath, "Options"), optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate: ctrl,")
+	g.p("caches:   %v(options, o.newCache%v),", g.qualify(cacheImportPath, "NewMethods"), strings.Join(append([]string{""}, names...), ", "))
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		if cm := cached[m.Name]; cm != nil {
+			g.p("")
+			generateCacheInvalidation(g, mockType, m, cm, outputPackagePath, shortTp)
+		}
+	}
+	for _, m := range intf.Methods {
+		g.p("")
+		generateCachedMethod(g, mockType, m, cached[m.Name], invalidations[m.Name], outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+// generateCacheInvalidation writes the methods removing the cached results
+// of m.
+func generateCacheInvalidation(g *generator, mockType string, m *model.Method, cm *callKey, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	keyNames, keyTypes := cm.keyParams(argNames, g.getArgTypes(m, pkgOverride, true))
+	ia := newIdentifierAllocator(keyNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// Invalidate%v removes the cached results of %v for the given arguments.", m.Name, m.Name)
+	g.p("func (%v *%v%v) Invalidate%v(%v) {", idRecv, mockType, shortTp, m.Name, makeArgString(keyNames, keyTypes))
+	g.in()
+	g.p("%v.caches[%q].Remove(%v)", idRecv, m.Name, cm.key(argNames, shortTp))
+	g.out()
+	g.p("}")
+	g.p("")
+	g.p("// Purge%v removes every cached result of %v.", m.Name, m.Name)
+	g.p("func (t *%v%v) Purge%v() {", mockType, shortTp, m.Name)
+	g.in()
+	g.p("t.caches[%q].Purge()", m.Name)
+	g.out()
+	g.p("}")
+}
+
+func generateCachedMethod(g *generator, mockType string, m *model.Method, cm *callKey, invalidates []string, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v caching base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	for _, target := range invalidates {
+		g.p("defer %v.Purge%v()", idRecv, target)
+	}
+	if cm == nil {
+		if len(rets) == 0 {
+			g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+		return
+	}
+
+	idKey := ia.allocateIdentifier("k")
+	idValue := ia.allocateIdentifier("v")
+	idOk := ia.allocateIdentifier("ok")
+	idRes := ia.allocateIdentifier("r")
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+
+	g.p("%v := %v", idKey, cm.key(argNames, shortTp))
+	g.p("if %v, %v := %v.caches[%q].Get(%v); %v {", idValue, idOk, idRecv, m.Name, idKey, idOk)
+	g.in()
+	g.p("%v := %v.(%v%v)", idRes, idValue, cm.resType, shortTp)
+	g.p("return %v", strings.Join(cm.resultFields(idRes, len(rets)), ", "))
+	g.out()
+	g.p("}")
+	g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+
+	// Only the results of the calls that succeed are cached.
+	add := fmt.Sprintf("%v.caches[%q].Add(%v, %v)", idRecv, m.Name, idKey, cm.results(returns, shortTp))
+	switch er := errorResultOf(m); {
+	case er.index < 0:
+		g.p("%v", add)
+	case !er.dynamic:
+		g.p("if %v == nil {", returns[er.index])
+		g.in()
+		g.p("%v", add)
+		g.out()
+		g.p("}")
+	default:
+		cond := fmt.Sprintf("_, %v := any(%v).(error); !%v", idOk, returns[er.index], idOk)
+		if er.pointer {
+			cond += fmt.Sprintf(" || %v == nil", returns[er.index])
+		}
+		g.p("if %v {", cond)
+		g.in()
+		g.p("%v", add)
+		g.out()
+		g.p("}")
+	}
+	g.p("return %v", strings.Join(returns, ", "))
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateCachedInterface(t *testing.T) {
+	inv, err := parseInvalidations("Store.Put=Get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := generator{
+		packageMap: map[string]string{"context": "context", cacheImportPath: "cache"},
+		cache:      cacheOptions{methods: parseSelectors("Store.Get"), invalidate: inv},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name:       "Len",
+		Out:        []*model.Parameter{{Type: model.PredeclaredType("int")}},
+		Directives: []string{"cache"},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name:       "Clear",
+		Directives: []string{"invalidates Get Len"},
+	})
+
+	if err := generateCachedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"type cachedStoreImplGetKey struct {\n\tkey string\n}",
+		"var _ map[cachedStoreImplGetKey]struct{}",
+		"type cachedStoreImplGetResults struct {\n\tr0 string\n\tr1 error\n}",
+		`caches:   cache.NewMethods(options, o.newCache, "Get", "Len"),`,
+		"func (t *CachedStoreImpl) InvalidateGet(key string) {",
+		`t.caches["Get"].Remove(cachedStoreImplGetKey{key: key})`,
+		"func (t *CachedStoreImpl) PurgeLen() {",
+		"k := cachedStoreImplGetKey{key: key}",
+		`if v, ok := t.caches["Get"].Get(k); ok {`,
+		"r := v.(cachedStoreImplGetResults)",
+		"return r.r0, r.r1",
+		"if ret_2 == nil {",
+		`t.caches["Get"].Add(k, cachedStoreImplGetResults{r0: ret, r1: ret_2})`,
+		`t.caches["Len"].Add(k, cachedStoreImplLenResults{r0: ret})`,
+		"defer t.PurgeGet()\n\treturn t.delegate.Put(ctx, key)",
+		"defer t.PurgeGet()\n\tdefer t.PurgeLen()\n\tt.delegate.Clear()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateCachedInterface_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		method *model.Method
+		want   string
+	}{
+		{
+			name: "slice",
+			method: &model.Method{
+				Name: "GetMany",
+				In:   []*model.Parameter{{Name: "keys", Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("string")}}},
+				Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+			},
+			want: `Store.GetMany: argument "keys" of type []string is not comparable`,
+		},
+		{
+			name: "interface",
+			method: &model.Method{
+				Name: "GetAny",
+				In:   []*model.Parameter{{Name: "key", Type: model.PredeclaredType("any")}},
+				Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+			},
+			want: `Store.GetAny: argument "key" is of interface type any`,
+		},
+		{
+			name: "variadic",
+			method: &model.Method{
+				Name:     "GetMany",
+				Variadic: &model.Parameter{Name: "keys", Type: model.PredeclaredType("string")},
+				Out:      []*model.Parameter{{Type: model.PredeclaredType("int")}},
+			},
+			want: `Store.GetMany: variadic argument "keys" is not comparable`,
+		},
+		{
+			name:   "no results",
+			method: &model.Method{Name: "GetMany"},
+			want:   "Store.GetMany has no results to cache",
+		},
+	} {
+		g := generator{cache: cacheOptions{methods: parseSelectors("Store")}}
+		intf := &model.Interface{Name: "Store"}
+		intf.AddMethod(tc.method)
+		err := generateCachedInterface(&g, intf, "somepackage")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: generateCachedInterface() = %v, want an error containing %q", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+// This file contains the keys of the calls of a method, with which decorators
+// share the results of a call with other calls of the same arguments.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// callKey describes the generated types of the keys and of the results of
+// the calls of a method.
+type callKey struct {
+	params  []int  // indexes of the arguments keying the calls
+	keyType string // unexported struct type of the keys
+	resType string // unexported struct type of the results
+}
+
+// newCallKey returns the key of the calls of m, whose types are named after
+// prefix. The key holds every argument of m but its context, and it is an
+// error if one is not comparable, or of an interface type.
+func (g *generator) newCallKey(intf *model.Interface, m *model.Method, prefix string) (*callKey, error) {
+	if m.Variadic != nil {
+		name := m.Variadic.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", len(m.In))
+		}
+		return nil, fmt.Errorf("%v.%v: variadic argument %q is not comparable and cannot key its calls", intf.Name, m.Name, name)
+	}
+	ck := &callKey{
+		keyType: prefix + m.Name + "Key",
+		resType: prefix + m.Name + "Results",
+	}
+	for i, p := range m.In {
+		if isContextType(p.Type) {
+			continue
+		}
+		name := p.Name
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		if g.isInterface(p.Type) {
+			return nil, fmt.Errorf("%v.%v: argument %q is of interface type %v, whose values may not be comparable, and cannot key its calls", intf.Name, m.Name, name, p.Type.String(nil, ""))
+		}
+		if !g.isComparable(p.Type) {
+			return nil, fmt.Errorf("%v.%v: argument %q of type %v is not comparable and cannot key its calls", intf.Name, m.Name, name, p.Type.String(nil, ""))
+		}
+		ck.params = append(ck.params, i)
+	}
+	return ck, nil
+}
+
+// generateCallKeyTypes writes the types of the keys and of the results of the
+// calls of m.
+func (g *generator) generateCallKeyTypes(m *model.Method, ck *callKey, longTp, pkgOverride string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	rets, _ := g.makeRetString(m, pkgOverride)
+
+	g.p("// %v keys the calls of %v.", ck.keyType, m.Name)
+	g.p("type %v%v struct {", ck.keyType, longTp)
+	g.in()
+	for _, i := range ck.params {
+		g.p("%v %v", argNames[i], argTypes[i])
+	}
+	g.out()
+	g.p("}")
+	g.p("")
+	if longTp == "" {
+		// Fails to compile if the arguments of a named type are not comparable.
+		g.p("var _ map[%v]struct{}", ck.keyType)
+		g.p("")
+	}
+	g.p("// %v holds the results of a call of %v.", ck.resType, m.Name)
+	g.p("type %v%v struct {", ck.resType, longTp)
+	g.in()
+	for i, ret := range rets {
+		g.p("r%d %v", i, ret)
+	}
+	g.out()
+	g.p("}")
+	g.p("")
+}
+
+// keyParams returns the names and types of the arguments keying the calls.
+func (ck *callKey) keyParams(argNames, argTypes []string) (names, types []string) {
+	names = make([]string, len(ck.params))
+	types = make([]string, len(ck.params))
+	for j, i := range ck.params {
+		names[j], types[j] = argNames[i], argTypes[i]
+	}
+	return names, types
+}
+
+// key returns the expression of the key of a call.
+func (ck *callKey) key(argNames []string, shortTp string) string {
+	fields := make([]string, len(ck.params))
+	for j, i := range ck.params {
+		fields[j] = argNames[i] + ": " + argNames[i]
+	}
+	return fmt.Sprintf("%v%v{%v}", ck.keyType, shortTp, strings.Join(fields, ", "))
+}
+
+// results returns the expression of the results held by returns.
+func (ck *callKey) results(returns []string, shortTp string) string {
+	fields := make([]string, len(returns))
+	for i, ret := range returns {
+		fields[i] = fmt.Sprintf("r%d: %v", i, ret)
+	}
+	return fmt.Sprintf("%v%v{%v}", ck.resType, shortTp, strings.Join(fields, ", "))
+}
+
+// resultFields returns the expressions of the results held by res.
+func (ck *callKey) resultFields(res string, n int) []string {
+	fields := make([]string, n)
+	for i := range fields {
+		fields[i] = fmt.Sprintf("%v.r%d", res, i)
+	}
+	return fields
+}
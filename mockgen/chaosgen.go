@@ -0,0 +1,108 @@
+package main
+
+// This file contains the chaos decorator, which injects latencies, errors and
+// panics into the calls of an implementation with the chaos package.
+
+import (
+	"sort"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const chaosImportPath = "github.com/pableeee/implgen/chaos"
+
+// chaosImports are the packages referenced by the chaos decorator.
+var chaosImports = map[string]string{
+	"context":       "",
+	chaosImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) chaosName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Chaos" + typeName + "Impl"
+}
+
+func generateChaosInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.chaosName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	injectorType := "*" + g.qualify(chaosImportPath, "Injector")
+
+	g.p("")
+	g.p("// %v is a chaos decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("injector %v", injectorType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	g.p("// New%v creates a new chaos decorator instance, injecting the faults", mockType)
+	g.p("// of injector into the calls. Defaults to injecting none if injector is")
+	g.p("// nil.")
+	g.p("func New%v%v(ctrl %v, injector %v) *%v%v {", mockType, longTp, intfType, injectorType, mockType, shortTp)
+	g.in()
+	g.p("if injector == nil {")
+	g.in()
+	g.p("injector = %v(0)", g.qualify(chaosImportPath, "New"))
+	g.out()
+	g.p("}")
+	g.p("return &%v%v{delegate: ctrl, injector: injector}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateChaosMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateChaosMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	ctx := g.qualify("context", "Background") + "()"
+	if i := contextArgIndex(m); i >= 0 {
+		ctx = argNames[i]
+	}
+
+	g.p("// %v chaos base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if returnsError(m) {
+		idErr := ia.allocateIdentifier("err")
+		returns := make([]string, len(rets))
+		for i := range rets {
+			returns[i] = ia.allocateIdentifier("ret")
+		}
+		g.p("if %v := %v.injector.Inject(%v, %q, true); %v != nil {", idErr, idRecv, ctx, intf.Name+"."+m.Name, idErr)
+		g.in()
+		g.generateErrorReturn(m, rets, returns, idErr)
+		g.out()
+		g.p("}")
+	} else {
+		// Methods that cannot fail only get the latencies and the panics.
+		g.p("_ = %v.injector.Inject(%v, %q, false)", idRecv, ctx, intf.Name+"."+m.Name)
+	}
+	if len(rets) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	}
+	g.out()
+	g.p("}")
+}
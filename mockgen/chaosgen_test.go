@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateChaosInterface(t *testing.T) {
+	g := generator{packageMap: map[string]string{"context": "context", chaosImportPath: "chaos"}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("string")},
+		},
+		Out: []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+
+	if err := generateChaosInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewChaosStoreImpl(ctrl Store, injector *chaos.Injector) *ChaosStoreImpl {\n\tif injector == nil {\n\t\tinjector = chaos.New(0)\n\t}",
+		"if err := t.injector.Inject(ctx, \"Store.Get\", true); err != nil {\n" +
+			"\t\tvar ret string\n" +
+			"\t\treturn ret, err\n" +
+			"\t}\n" +
+			"\treturn t.delegate.Get(ctx, key)",
+		"_ = t.injector.Inject(context.Background(), \"Store.Len\", false)\n\treturn t.delegate.Len()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
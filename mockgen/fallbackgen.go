@@ -0,0 +1,147 @@
+package main
+
+// This file contains the fallback decorator, which calls a secondary
+// implementation of an interface when the primary one fails.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const fallbackImportPath = "github.com/pableeee/implgen/fallback"
+
+// fallbackImports are the packages referenced by the fallback decorator.
+var fallbackImports = map[string]string{
+	fallbackImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) fallbackName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return typeName + "Fallback"
+}
+
+func generateFallbackInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.fallbackName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	servedType := fmt.Sprintf("func(method string, served %v, err error)", g.qualify(fallbackImportPath, "Backend"))
+
+	g.p("")
+	g.p("// %v is a fallback decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("primary   %v", intfType)
+	g.p("secondary %v", intfType)
+	g.p("accept    func(err error) bool")
+	g.p("served    %v", servedType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "Accept",
+			param: "accept",
+			field: "accept",
+			typ:   "func(err error) bool",
+			def:   g.qualify(fallbackImportPath, "Accept"),
+			doc: "sets the function reporting whether the secondary\n" +
+				"of a " + mockType + " is called when its primary fails with err.\n" +
+				"Defaults to fallback.Accept, accepting every error but the ones of a\n" +
+				"context that is done.",
+		},
+		{
+			name:  "OnServed",
+			param: "served",
+			field: "served",
+			typ:   servedType,
+			def:   fmt.Sprintf("func(string, %v, error) {}", g.qualify(fallbackImportPath, "Backend")),
+			doc: "sets the function called after every call of a\n" +
+				mockType + ", with the Interface.Method name, the implementation\n" +
+				"that served it and the error it returned, if any.",
+		},
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new fallback decorator instance, calling secondary", mockType)
+	g.p("// when primary fails.")
+	g.p("func New%v%v(primary, secondary %v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("primary:   primary,")
+	g.p("secondary: secondary,")
+	g.p("accept:    o.accept,")
+	g.p("served:    o.served,")
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateFallbackMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateFallbackMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+	method := intf.Name + "." + m.Name
+	primary := g.qualify(fallbackImportPath, "Primary")
+
+	g.p("// %v fallback base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if !returnsError(m) {
+		// Methods that cannot fail only call the primary.
+		g.p("defer %v.served(%q, %v, nil)", idRecv, method, primary)
+		if len(rets) == 0 {
+			g.p("%v.primary.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.primary.%v(%v)", idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+		return
+	}
+
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+	retList := strings.Join(returns, ", ")
+	idErr := returns[errorResultOf(m).index]
+
+	g.p("%v := %v.primary.%v(%v)", retList, idRecv, m.Name, callArgs)
+	g.p("if %v == nil || !%v.accept(%v) {", idErr, idRecv, idErr)
+	g.in()
+	g.p("%v.served(%q, %v, %v)", idRecv, method, primary, idErr)
+	g.p("return %v", retList)
+	g.out()
+	g.p("}")
+	g.p("%v = %v.secondary.%v(%v)", retList, idRecv, m.Name, callArgs)
+	g.p("%v.served(%q, %v, %v)", idRecv, method, g.qualify(fallbackImportPath, "Secondary"), idErr)
+	g.p("return %v", retList)
+	g.out()
+	g.p("}")
+}
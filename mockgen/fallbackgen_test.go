@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateFallbackInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", fallbackImportPath: "fallback"},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, {Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateFallbackInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"type StoreFallback struct {",
+		"func NewStoreFallback(primary, secondary Store, opts ...StoreFallbackOption) *StoreFallback {",
+		"o := storeFallbackOptions{accept: fallback.Accept, served: func(string, fallback.Backend, error) {}}",
+		"func WithStoreFallbackOnServed(served func(method string, served fallback.Backend, err error)) StoreFallbackOption {",
+		"ret, ret_2 := t.primary.Get(ctx, key)\n\tif ret_2 == nil || !t.accept(ret_2) {\n\t\tt.served(\"Store.Get\", fallback.Primary, ret_2)\n\t\treturn ret, ret_2\n\t}",
+		"ret, ret_2 = t.secondary.Get(ctx, key)\n\tt.served(\"Store.Get\", fallback.Secondary, ret_2)\n\treturn ret, ret_2",
+		"defer t.served(\"Store.Len\", fallback.Primary, nil)\n\treturn t.primary.Len()",
+		"defer t.served(\"Store.Close\", fallback.Primary, nil)\n\tt.primary.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "t.secondary.Len") || strings.Contains(out, "t.secondary.Close") {
+		t.Errorf("generated code calls the secondary in methods that cannot fail:\n%s", out)
+	}
+}
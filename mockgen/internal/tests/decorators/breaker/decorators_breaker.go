@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=breaker/decorators_breaker.go -package breaker -implementation_type=breaker
+//
+
+// Package breaker is a generated GoMock package.
+package breaker
+
+import (
+	context "context"
+	time "time"
+
+	breaker "github.com/pableeee/implgen/breaker"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// BreakingStoreImpl is a circuit breaker decorator of Store interface.
+type BreakingStoreImpl struct {
+	delegate  decorators.Store
+	breakers  map[string]*breaker.Breaker
+	isFailure func(error) bool
+}
+
+var _ decorators.Store = (*BreakingStoreImpl)(nil)
+
+// BreakingStoreImplOption configures a BreakingStoreImpl.
+type BreakingStoreImplOption func(*breakingStoreImplOptions)
+
+type breakingStoreImplOptions struct {
+	scope     breaker.Scope
+	isFailure func(err error) bool
+}
+
+// WithBreakingStoreImplScope sets whether a BreakingStoreImpl tracks the failures
+// of its methods with a breaker per method or with a single one.
+func WithBreakingStoreImplScope(scope breaker.Scope) BreakingStoreImplOption {
+	return func(o *breakingStoreImplOptions) {
+		o.scope = scope
+	}
+}
+
+// WithBreakingStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a BreakingStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithBreakingStoreImplErrorClassifier(isFailure func(err error) bool) BreakingStoreImplOption {
+	return func(o *breakingStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewBreakingStoreImpl creates a new circuit breaker decorator instance, whose
+// breakers are created with settings.
+func NewBreakingStoreImpl(ctrl decorators.Store, settings breaker.Settings, opts ...BreakingStoreImplOption) *BreakingStoreImpl {
+	o := breakingStoreImplOptions{scope: breaker.PerMethod, isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &BreakingStoreImpl{
+		delegate:  ctrl,
+		breakers:  breaker.NewMethods(settings, o.scope, "Store", "Get", "Put"),
+		isFailure: o.isFailure,
+	}
+	return deco
+}
+
+// Breaker returns the circuit breaker of the method, or nil if it has none.
+func (t *BreakingStoreImpl) Breaker(method string) *breaker.Breaker {
+	return t.breakers[method]
+}
+
+// Close circuit breaking base method.
+func (t *BreakingStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get circuit breaking base method.
+func (t *BreakingStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	done, err := t.breakers["Get"].Allow()
+	if err != nil {
+		var ret []byte
+		return ret, err
+	}
+	failed := true
+	defer func() {
+		done(failed)
+	}()
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	failed = ret_2 != nil && t.isFailure(ret_2)
+	return ret, ret_2
+}
+
+// Keys circuit breaking base method.
+func (t *BreakingStoreImpl) Keys(prefix string, limit int) []string {
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put circuit breaking base method.
+func (t *BreakingStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	done, err := t.breakers["Put"].Allow()
+	if err != nil {
+		return err
+	}
+	failed := true
+	defer func() {
+		done(failed)
+	}()
+	ret := t.delegate.Put(ctx, key, value)
+	failed = ret != nil && t.isFailure(ret)
+	return ret
+}
+
+// BreakingPaymentsImpl is a circuit breaker decorator of Payments interface.
+type BreakingPaymentsImpl struct {
+	delegate  decorators.Payments
+	breakers  map[string]*breaker.Breaker
+	isFailure func(error) bool
+}
+
+var _ decorators.Payments = (*BreakingPaymentsImpl)(nil)
+
+// BreakingPaymentsImplOption configures a BreakingPaymentsImpl.
+type BreakingPaymentsImplOption func(*breakingPaymentsImplOptions)
+
+type breakingPaymentsImplOptions struct {
+	scope     breaker.Scope
+	isFailure func(err error) bool
+}
+
+// WithBreakingPaymentsImplScope sets whether a BreakingPaymentsImpl tracks the failures
+// of its methods with a breaker per method or with a single one.
+func WithBreakingPaymentsImplScope(scope breaker.Scope) BreakingPaymentsImplOption {
+	return func(o *breakingPaymentsImplOptions) {
+		o.scope = scope
+	}
+}
+
+// WithBreakingPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a BreakingPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithBreakingPaymentsImplErrorClassifier(isFailure func(err error) bool) BreakingPaymentsImplOption {
+	return func(o *breakingPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewBreakingPaymentsImpl creates a new circuit breaker decorator instance, whose
+// breakers are created with settings.
+func NewBreakingPaymentsImpl(ctrl decorators.Payments, settings breaker.Settings, opts ...BreakingPaymentsImplOption) *BreakingPaymentsImpl {
+	o := breakingPaymentsImplOptions{scope: breaker.PerMethod, isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &BreakingPaymentsImpl{
+		delegate:  ctrl,
+		breakers:  breaker.NewMethods(settings, o.scope, "Payments", "Capture", "Charge", "Refund"),
+		isFailure: o.isFailure,
+	}
+	return deco
+}
+
+// Breaker returns the circuit breaker of the method, or nil if it has none.
+func (t *BreakingPaymentsImpl) Breaker(method string) *breaker.Breaker {
+	return t.breakers[method]
+}
+
+// Capture circuit breaking base method.
+func (t *BreakingPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	done, err := t.breakers["Capture"].Allow()
+	if err != nil {
+		return err
+	}
+	failed := true
+	defer func() {
+		done(failed)
+	}()
+	ret := t.delegate.Capture(receipt, ctx)
+	failed = ret != nil && t.isFailure(ret)
+	return ret
+}
+
+// Charge circuit breaking base method.
+func (t *BreakingPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	done, err := t.breakers["Charge"].Allow()
+	if err != nil {
+		var ret string
+		return ret, err
+	}
+	failed := true
+	defer func() {
+		done(failed)
+	}()
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	failed = ret_2 != nil && t.isFailure(ret_2)
+	return ret, ret_2
+}
+
+// Refund circuit breaking base method.
+func (t *BreakingPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	done, err := t.breakers["Refund"].Allow()
+	if err != nil {
+		return err
+	}
+	failed := true
+	defer func() {
+		done(failed)
+	}()
+	ret := t.delegate.Refund(req)
+	failed = ret != nil && t.isFailure(ret)
+	return ret
+}
@@ -0,0 +1,112 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/breaker"
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// fakeClock is a breaker.Clock whose time only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// failingStore is a Store whose Get and Put calls fail with err.
+type failingStore struct {
+	decorators.MemStore
+	err error
+}
+
+func (s failingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.MemStore.Get(ctx, key)
+}
+
+func (s failingStore) Put(context.Context, string, []byte) error {
+	return s.err
+}
+
+func TestBreakingStoreImpl(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var changes []string
+	store := &failingStore{MemStore: decorators.MemStore{"a": []byte("1")}, err: errors.New("down")}
+	s := NewBreakingStoreImpl(store, breaker.Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		Clock:            clock,
+		OnStateChange: func(name string, from, to breaker.State) {
+			changes = append(changes, fmt.Sprintf("%v: %v -> %v", name, from, to))
+		},
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := s.Get(ctx, "a"); err != store.err {
+			t.Fatalf("Get(a) = %v, want %v", err, store.err)
+		}
+	}
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, breaker.ErrCircuitOpen) {
+		t.Fatalf("Get(a) = %v, want %v", err, breaker.ErrCircuitOpen)
+	}
+	// The breakers are per method.
+	if err := s.Put(ctx, "a", nil); err != store.err {
+		t.Errorf("Put(a) = %v, want %v", err, store.err)
+	}
+
+	store.err = nil
+	clock.now = clock.now.Add(time.Second)
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+	if got := s.Breaker("Get").State(); got != breaker.Closed {
+		t.Errorf("Get breaker is %v, want closed", got)
+	}
+	if s.Breaker("Keys") != nil {
+		t.Error("Keys has a breaker, want none")
+	}
+
+	want := []string{"Store.Get: closed -> open", "Store.Get: open -> half-open", "Store.Get: half-open -> closed"}
+	if fmt.Sprint(changes) != fmt.Sprint(want) {
+		t.Errorf("state changes = %q, want %q", changes, want)
+	}
+}
+
+func TestBreakingStoreImpl_Options(t *testing.T) {
+	store := &failingStore{err: context.Canceled}
+	s := NewBreakingStoreImpl(store, breaker.Settings{FailureThreshold: 1},
+		WithBreakingStoreImplScope(breaker.PerInterface),
+		WithBreakingStoreImplErrorClassifier(func(err error) bool { return !errors.Is(err, context.Canceled) }))
+
+	ctx := context.Background()
+	_ = s.Put(ctx, "a", nil)
+	if _, err := s.Get(ctx, "a"); err != context.Canceled {
+		t.Fatalf("Get(a) = %v, want %v", err, context.Canceled)
+	}
+
+	store.err = errors.New("down")
+	_ = s.Put(ctx, "a", nil)
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, breaker.ErrCircuitOpen) {
+		t.Errorf("Get(a) = %v, want %v", err, breaker.ErrCircuitOpen)
+	}
+}
+
+func TestBreakingStoreImpl_Panic(t *testing.T) {
+	s := NewBreakingStoreImpl(decorators.PanickingStore{}, breaker.Settings{FailureThreshold: 1})
+
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = s.Get(context.Background(), "a")
+	}()
+	if got := s.Breaker("Get").State(); got != breaker.Open {
+		t.Errorf("Get breaker is %v after a panic, want open", got)
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=cache/decorators_cache.go -package cache -implementation_type=cache -cache_methods=Store.Get,Store.Keys -cache_invalidate=Store.Put=Get,Store.Put=Keys
+//
+
+// Package cache is a generated GoMock package.
+package cache
+
+import (
+	context "context"
+	time "time"
+
+	cache "github.com/pableeee/implgen/cache"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// CachedStoreImpl is a caching decorator of Store interface.
+type CachedStoreImpl struct {
+	delegate decorators.Store
+	caches   map[string]cache.Cache
+}
+
+var _ decorators.Store = (*CachedStoreImpl)(nil)
+
+// cachedStoreImplGetKey keys the calls of Get.
+type cachedStoreImplGetKey struct {
+	key string
+}
+
+var _ map[cachedStoreImplGetKey]struct{}
+
+// cachedStoreImplGetResults holds the results of a call of Get.
+type cachedStoreImplGetResults struct {
+	r0 []byte
+	r1 error
+}
+
+// cachedStoreImplKeysKey keys the calls of Keys.
+type cachedStoreImplKeysKey struct {
+	prefix string
+	limit  int
+}
+
+var _ map[cachedStoreImplKeysKey]struct{}
+
+// cachedStoreImplKeysResults holds the results of a call of Keys.
+type cachedStoreImplKeysResults struct {
+	r0 []string
+}
+
+// CachedStoreImplOption configures a CachedStoreImpl.
+type CachedStoreImplOption func(*cachedStoreImplOptions)
+
+type cachedStoreImplOptions struct {
+	newCache func(method string) cache.Cache
+}
+
+// WithCachedStoreImplCaches sets the function creating the cache of each
+// method of a CachedStoreImpl whose results are cached, in place of
+// the LRUs created with the options passed to its constructor.
+func WithCachedStoreImplCaches(newCache func(method string) cache.Cache) CachedStoreImplOption {
+	return func(o *cachedStoreImplOptions) {
+		o.newCache = newCache
+	}
+}
+
+// NewCachedStoreImpl creates a new caching decorator instance, whose caches are
+// created with options.
+func NewCachedStoreImpl(ctrl decorators.Store, options cache.Options, opts ...CachedStoreImplOption) *CachedStoreImpl {
+	o := cachedStoreImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &CachedStoreImpl{
+		delegate: ctrl,
+		caches:   cache.NewMethods(options, o.newCache, "Get", "Keys"),
+	}
+	return deco
+}
+
+// InvalidateGet removes the cached results of Get for the given arguments.
+func (t *CachedStoreImpl) InvalidateGet(key string) {
+	t.caches["Get"].Remove(cachedStoreImplGetKey{key: key})
+}
+
+// PurgeGet removes every cached result of Get.
+func (t *CachedStoreImpl) PurgeGet() {
+	t.caches["Get"].Purge()
+}
+
+// InvalidateKeys removes the cached results of Keys for the given arguments.
+func (t *CachedStoreImpl) InvalidateKeys(prefix string, limit int) {
+	t.caches["Keys"].Remove(cachedStoreImplKeysKey{prefix: prefix, limit: limit})
+}
+
+// PurgeKeys removes every cached result of Keys.
+func (t *CachedStoreImpl) PurgeKeys() {
+	t.caches["Keys"].Purge()
+}
+
+// Close caching base method.
+func (t *CachedStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get caching base method.
+func (t *CachedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	k := cachedStoreImplGetKey{key: key}
+	if v, ok := t.caches["Get"].Get(k); ok {
+		r := v.(cachedStoreImplGetResults)
+		return r.r0, r.r1
+	}
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	if ret_2 == nil {
+		t.caches["Get"].Add(k, cachedStoreImplGetResults{r0: ret, r1: ret_2})
+	}
+	return ret, ret_2
+}
+
+// Keys caching base method.
+func (t *CachedStoreImpl) Keys(prefix string, limit int) []string {
+	k := cachedStoreImplKeysKey{prefix: prefix, limit: limit}
+	if v, ok := t.caches["Keys"].Get(k); ok {
+		r := v.(cachedStoreImplKeysResults)
+		return r.r0
+	}
+	ret := t.delegate.Keys(prefix, limit)
+	t.caches["Keys"].Add(k, cachedStoreImplKeysResults{r0: ret})
+	return ret
+}
+
+// Put caching base method.
+func (t *CachedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	defer t.PurgeGet()
+	defer t.PurgeKeys()
+	return t.delegate.Put(ctx, key, value)
+}
+
+// CachedPaymentsImpl is a caching decorator of Payments interface.
+type CachedPaymentsImpl struct {
+	delegate decorators.Payments
+	caches   map[string]cache.Cache
+}
+
+var _ decorators.Payments = (*CachedPaymentsImpl)(nil)
+
+// CachedPaymentsImplOption configures a CachedPaymentsImpl.
+type CachedPaymentsImplOption func(*cachedPaymentsImplOptions)
+
+type cachedPaymentsImplOptions struct {
+	newCache func(method string) cache.Cache
+}
+
+// WithCachedPaymentsImplCaches sets the function creating the cache of each
+// method of a CachedPaymentsImpl whose results are cached, in place of
+// the LRUs created with the options passed to its constructor.
+func WithCachedPaymentsImplCaches(newCache func(method string) cache.Cache) CachedPaymentsImplOption {
+	return func(o *cachedPaymentsImplOptions) {
+		o.newCache = newCache
+	}
+}
+
+// NewCachedPaymentsImpl creates a new caching decorator instance, whose caches are
+// created with options.
+func NewCachedPaymentsImpl(ctrl decorators.Payments, options cache.Options, opts ...CachedPaymentsImplOption) *CachedPaymentsImpl {
+	o := cachedPaymentsImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &CachedPaymentsImpl{
+		delegate: ctrl,
+		caches:   cache.NewMethods(options, o.newCache),
+	}
+	return deco
+}
+
+// Capture caching base method.
+func (t *CachedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	return t.delegate.Capture(receipt, ctx)
+}
+
+// Charge caching base method.
+func (t *CachedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund caching base method.
+func (t *CachedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	return t.delegate.Refund(req)
+}
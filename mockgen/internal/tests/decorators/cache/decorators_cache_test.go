@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/cache"
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// countingStore is a Store counting the calls of its methods.
+type countingStore struct {
+	decorators.MemStore
+	calls map[string]int
+}
+
+func (s countingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.calls["Get"]++
+	return s.MemStore.Get(ctx, key)
+}
+
+func (s countingStore) Keys(prefix string, limit int) []string {
+	s.calls["Keys"]++
+	return s.MemStore.Keys(prefix, limit)
+}
+
+// fakeClock is a cache.Clock whose time only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestCachedStoreImpl(t *testing.T) {
+	store := countingStore{MemStore: decorators.MemStore{"a": []byte("1")}, calls: map[string]int{}}
+	s := NewCachedStoreImpl(store, cache.Options{})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+			t.Fatalf("Get(a) = %q, %v, want 1, nil", v, err)
+		}
+		// Failures are not cached.
+		if _, err := s.Get(ctx, "b"); err != decorators.ErrNotFound {
+			t.Fatalf("Get(b) = %v, want %v", err, decorators.ErrNotFound)
+		}
+		_ = s.Keys("", 10)
+	}
+	if want := map[string]int{"Get": 3, "Keys": 1}; store.calls["Get"] != want["Get"] || store.calls["Keys"] != want["Keys"] {
+		t.Errorf("delegate calls = %v, want %v", store.calls, want)
+	}
+
+	// Put purges the cached results of Get and Keys.
+	if err := s.Put(ctx, "a", []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := s.Get(ctx, "a"); string(v) != "2" {
+		t.Errorf("Get(a) = %q after Put, want 2", v)
+	}
+	if keys := s.Keys("", 10); len(keys) != 1 || store.calls["Keys"] != 2 {
+		t.Errorf("Keys() = %q after Put with %d delegate calls, want [a] with 2", keys, store.calls["Keys"])
+	}
+
+	store.MemStore["a"] = []byte("3")
+	s.InvalidateGet("a")
+	if v, _ := s.Get(ctx, "a"); string(v) != "3" {
+		t.Errorf("Get(a) = %q after InvalidateGet, want 3", v)
+	}
+}
+
+func TestCachedStoreImpl_TTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := countingStore{MemStore: decorators.MemStore{"a": []byte("1")}, calls: map[string]int{}}
+	s := NewCachedStoreImpl(store, cache.Options{TTL: time.Minute, Clock: clock})
+
+	ctx := context.Background()
+	_, _ = s.Get(ctx, "a")
+	_, _ = s.Get(ctx, "a")
+	clock.now = clock.now.Add(time.Minute)
+	_, _ = s.Get(ctx, "a")
+	if store.calls["Get"] != 2 {
+		t.Errorf("Get called the delegate %d times, want 2", store.calls["Get"])
+	}
+}
+
+func TestCachedStoreImpl_Caches(t *testing.T) {
+	shared := cache.New(cache.Options{})
+	s := NewCachedStoreImpl(decorators.MemStore{"a": nil}, cache.Options{},
+		WithCachedStoreImplCaches(func(string) cache.Cache { return shared }))
+
+	_ = s.Keys("", 10)
+	if n := shared.Len(); n != 1 {
+		t.Errorf("cache holds %d results, want 1", n)
+	}
+}
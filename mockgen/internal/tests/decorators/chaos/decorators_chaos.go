@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=chaos/decorators_chaos.go -package chaos -implementation_type=chaos
+//
+
+// Package chaos is a generated GoMock package.
+package chaos
+
+import (
+	context "context"
+	time "time"
+
+	chaos "github.com/pableeee/implgen/chaos"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// ChaosStoreImpl is a chaos decorator of Store interface.
+type ChaosStoreImpl struct {
+	delegate decorators.Store
+	injector *chaos.Injector
+}
+
+var _ decorators.Store = (*ChaosStoreImpl)(nil)
+
+// NewChaosStoreImpl creates a new chaos decorator instance, injecting the faults
+// of injector into the calls. Defaults to injecting none if injector is
+// nil.
+func NewChaosStoreImpl(ctrl decorators.Store, injector *chaos.Injector) *ChaosStoreImpl {
+	if injector == nil {
+		injector = chaos.New(0)
+	}
+	return &ChaosStoreImpl{delegate: ctrl, injector: injector}
+}
+
+// Close chaos base method.
+func (t *ChaosStoreImpl) Close() {
+	_ = t.injector.Inject(context.Background(), "Store.Close", false)
+	t.delegate.Close()
+}
+
+// Get chaos base method.
+func (t *ChaosStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := t.injector.Inject(ctx, "Store.Get", true); err != nil {
+		var ret []byte
+		return ret, err
+	}
+	return t.delegate.Get(ctx, key)
+}
+
+// Keys chaos base method.
+func (t *ChaosStoreImpl) Keys(prefix string, limit int) []string {
+	_ = t.injector.Inject(context.Background(), "Store.Keys", false)
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put chaos base method.
+func (t *ChaosStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	if err := t.injector.Inject(ctx, "Store.Put", true); err != nil {
+		return err
+	}
+	return t.delegate.Put(ctx, key, value)
+}
+
+// ChaosPaymentsImpl is a chaos decorator of Payments interface.
+type ChaosPaymentsImpl struct {
+	delegate decorators.Payments
+	injector *chaos.Injector
+}
+
+var _ decorators.Payments = (*ChaosPaymentsImpl)(nil)
+
+// NewChaosPaymentsImpl creates a new chaos decorator instance, injecting the faults
+// of injector into the calls. Defaults to injecting none if injector is
+// nil.
+func NewChaosPaymentsImpl(ctrl decorators.Payments, injector *chaos.Injector) *ChaosPaymentsImpl {
+	if injector == nil {
+		injector = chaos.New(0)
+	}
+	return &ChaosPaymentsImpl{delegate: ctrl, injector: injector}
+}
+
+// Capture chaos base method.
+func (t *ChaosPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	if err := t.injector.Inject(ctx, "Payments.Capture", true); err != nil {
+		return err
+	}
+	return t.delegate.Capture(receipt, ctx)
+}
+
+// Charge chaos base method.
+func (t *ChaosPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	if err := t.injector.Inject(ctx, "Payments.Charge", true); err != nil {
+		var ret string
+		return ret, err
+	}
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund chaos base method.
+func (t *ChaosPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	if err := t.injector.Inject(context.Background(), "Payments.Refund", true); err != nil {
+		return err
+	}
+	return t.delegate.Refund(req)
+}
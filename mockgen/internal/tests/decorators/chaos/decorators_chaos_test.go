@@ -0,0 +1,66 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/chaos"
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestChaosStoreImpl(t *testing.T) {
+	rules, err := chaos.LoadFile("testdata/rules.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewChaosStoreImpl(decorators.MemStore{"a": []byte("1")}, chaos.New(1, rules...))
+
+	ctx := context.Background()
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get() = %q, %v, want 1, nil", v, err)
+	}
+	if v, err := s.Get(ctx, "a"); !errors.Is(err, chaos.ErrInjected) || v != nil {
+		t.Errorf("Get() = %q, %v, want nil and an injected error", v, err)
+	}
+	if _, err := s.Get(ctx, "a"); err != nil {
+		t.Errorf("Get() = %v, want nil once the rule is exhausted", err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "keys lost" {
+				t.Errorf("Keys() panicked with %v, want keys lost", r)
+			}
+		}()
+		s.Keys("", 10)
+	}()
+}
+
+func TestChaosStoreImpl_Seed(t *testing.T) {
+	rules, err := chaos.LoadFile("testdata/rules.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	puts := func() []bool {
+		s := NewChaosStoreImpl(decorators.MemStore{}, chaos.New(7, rules...))
+		var failed []bool
+		for i := 0; i < 20; i++ {
+			failed = append(failed, s.Put(context.Background(), "a", nil) != nil)
+		}
+		return failed
+	}
+	a, b := puts(), puts()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Put() %d failed with one seeded decorator but not with the other", i)
+		}
+	}
+}
+
+func TestChaosStoreImpl_NilInjector(t *testing.T) {
+	s := NewChaosStoreImpl(decorators.MemStore{"a": []byte("1")}, nil)
+	if v, err := s.Get(context.Background(), "a"); string(v) != "1" || err != nil {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+}
@@ -0,0 +1,68 @@
+// Package decorators contains interfaces used to exercise the decorators
+// generated by the non-mock implementation types.
+package decorators
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+//go:generate mockgen -source=decorators.go -destination=metrics/decorators_metrics.go -package metrics -implementation_type=metrics
+//go:generate mockgen -source=decorators.go -destination=otelmetrics/decorators_metrics.go -package otelmetrics -implementation_type=metrics -metrics_backend=otel -metrics_unit=milliseconds
+//go:generate mockgen -source=decorators.go -destination=expvarmetrics/decorators_metrics.go -package expvarmetrics -implementation_type=metrics -metrics_backend=expvar -metrics_namespace=shop
+//go:generate mockgen -source=decorators.go -destination=trace/decorators_trace.go -package trace -implementation_type=trace -attribute_args=Payments -attribute_results=Payments.Charge -attribute_exclude=token -trace_context_free=link
+//go:generate mockgen -source=decorators.go -destination=logging/decorators_logging.go -package logging -implementation_type=logging -attribute_args=Store,Payments -attribute_results=Payments.Charge -attribute_exclude=value,token -logging_success_level=info
+//go:generate mockgen -source=decorators.go -destination=retry/decorators_retry.go -package retry -implementation_type=retry -retry_non_idempotent=Payments.Refund
+//go:generate mockgen -source=decorators.go -destination=breaker/decorators_breaker.go -package breaker -implementation_type=breaker
+//go:generate mockgen -source=decorators.go -destination=ratelimit/decorators_ratelimit.go -package ratelimit -implementation_type=ratelimit
+//go:generate mockgen -source=decorators.go -destination=timeout/decorators_timeout.go -package timeout -implementation_type=timeout
+//go:generate mockgen -source=decorators.go -destination=cache/decorators_cache.go -package cache -implementation_type=cache -cache_methods=Store.Get,Store.Keys -cache_invalidate=Store.Put=Get,Store.Put=Keys
+//go:generate mockgen -source=decorators.go -destination=singleflight/decorators_singleflight.go -package singleflight -implementation_type=singleflight -singleflight_methods=Store,Payments
+//go:generate mockgen -source=decorators.go -destination=fallback/decorators_fallback.go -package fallback -implementation_type=fallback
+//go:generate mockgen -source=decorators.go -destination=shadow/decorators_shadow.go -package shadow -implementation_type=shadow -shadow_methods=Store.Get,Store.Keys
+//go:generate mockgen -source=decorators.go -destination=multi/decorators_multi.go -package multi -implementation_type=multi
+//go:generate mockgen -source=decorators.go -destination=stub/decorators_stub.go -package stub -implementation_type=stub -stub_record
+//go:generate mockgen -source=decorators.go -destination=record/decorators_record.go -package record -implementation_type=record
+//go:generate mockgen -source=decorators.go -destination=chaos/decorators_chaos.go -package chaos -implementation_type=chaos
+//go:generate mockgen -source=decorators.go -destination=synchronized/decorators_synchronized.go -package synchronized -implementation_type=synchronized -synchronized_readonly=Store.Get,Store.Keys -synchronized_context
+
+// Store is a key-value store.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, value []byte) error
+	Keys(prefix string, limit int) []string
+	Close()
+}
+
+// Payments charges accounts.
+type Payments interface {
+	// Charge charges an account; a charge that timed out may have been made.
+	//implgen:nonidempotent
+	Charge(ctx context.Context, account Account, amount int64, timeout time.Duration, token string) (receipt string, err error)
+	Capture(receipt string, ctx context.Context) error
+	Refund(req *RefundRequest) error
+}
+
+// Account is a payments account.
+type Account struct {
+	ID string
+}
+
+// SpanAttributes returns the attributes recording the account under key.
+func (a Account) SpanAttributes(key string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String(key+".id", a.ID)}
+}
+
+// RefundRequest is a request to refund a charge, carrying the context of the
+// caller.
+type RefundRequest struct {
+	Ctx     context.Context
+	Receipt string
+}
+
+// Context returns the context of the caller.
+func (r *RefundRequest) Context() context.Context {
+	return r.Ctx
+}
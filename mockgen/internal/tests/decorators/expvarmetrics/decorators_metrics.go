@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=expvarmetrics/decorators_metrics.go -package expvarmetrics -implementation_type=metrics -metrics_backend=expvar -metrics_namespace=shop
+//
+
+// Package expvarmetrics is a generated GoMock package.
+package expvarmetrics
+
+import (
+	context "context"
+	expvar "expvar"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// MetricsStoreImpl is a metrics decorator of Store interface.
+type MetricsStoreImpl struct {
+	vars      *expvar.Map
+	delegate  decorators.Store
+	isFailure func(error) bool
+}
+
+var _ decorators.Store = (*MetricsStoreImpl)(nil)
+
+// MetricsStoreImplOption configures a MetricsStoreImpl.
+type MetricsStoreImplOption func(*metricsStoreImplOptions)
+
+type metricsStoreImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsStoreImplErrorClassifier(isFailure func(err error) bool) MetricsStoreImplOption {
+	return func(o *metricsStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsStoreImpl creates a new metrics decorator instance.
+func NewMetricsStoreImpl(ctrl decorators.Store, opts ...MetricsStoreImplOption) *MetricsStoreImpl {
+	o := metricsStoreImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsStoreImpl{delegate: ctrl, isFailure: o.isFailure}
+	deco.vars, _ = expvar.Get("shop_Store").(*expvar.Map)
+	if deco.vars == nil {
+		deco.vars = expvar.NewMap("shop_Store")
+	}
+	return deco
+}
+
+// Close Metrics base method.
+func (t *MetricsStoreImpl) Close() {
+	t.vars.Add("Close.calls_total", 1)
+	t.vars.Add("Close.in_flight", 1)
+	defer t.vars.Add("Close.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Close.errors_total", 1)
+			t.vars.Add("Close.panics_total", 1)
+			t.vars.AddFloat("Close.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	t.delegate.Close()
+	took := time.Since(begin)
+	t.vars.AddFloat("Close.duration_seconds", took.Seconds())
+}
+
+// Get Metrics base method.
+func (t *MetricsStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	t.vars.Add("Get.calls_total", 1)
+	t.vars.Add("Get.in_flight", 1)
+	defer t.vars.Add("Get.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Get.errors_total", 1)
+			t.vars.Add("Get.panics_total", 1)
+			t.vars.AddFloat("Get.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	took := time.Since(begin)
+	if ret_2 != nil && t.isFailure(ret_2) {
+		t.vars.Add("Get.errors_total", 1)
+	}
+	t.vars.AddFloat("Get.duration_seconds", took.Seconds())
+	return ret, ret_2
+}
+
+// Keys Metrics base method.
+func (t *MetricsStoreImpl) Keys(prefix string, limit int) []string {
+	t.vars.Add("Keys.calls_total", 1)
+	t.vars.Add("Keys.in_flight", 1)
+	defer t.vars.Add("Keys.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Keys.errors_total", 1)
+			t.vars.Add("Keys.panics_total", 1)
+			t.vars.AddFloat("Keys.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Keys(prefix, limit)
+	took := time.Since(begin)
+	t.vars.AddFloat("Keys.duration_seconds", took.Seconds())
+	return ret
+}
+
+// Put Metrics base method.
+func (t *MetricsStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	t.vars.Add("Put.calls_total", 1)
+	t.vars.Add("Put.in_flight", 1)
+	defer t.vars.Add("Put.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Put.errors_total", 1)
+			t.vars.Add("Put.panics_total", 1)
+			t.vars.AddFloat("Put.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Put(ctx, key, value)
+	took := time.Since(begin)
+	if ret != nil && t.isFailure(ret) {
+		t.vars.Add("Put.errors_total", 1)
+	}
+	t.vars.AddFloat("Put.duration_seconds", took.Seconds())
+	return ret
+}
+
+// MetricsPaymentsImpl is a metrics decorator of Payments interface.
+type MetricsPaymentsImpl struct {
+	vars      *expvar.Map
+	delegate  decorators.Payments
+	isFailure func(error) bool
+}
+
+var _ decorators.Payments = (*MetricsPaymentsImpl)(nil)
+
+// MetricsPaymentsImplOption configures a MetricsPaymentsImpl.
+type MetricsPaymentsImplOption func(*metricsPaymentsImplOptions)
+
+type metricsPaymentsImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsPaymentsImplErrorClassifier(isFailure func(err error) bool) MetricsPaymentsImplOption {
+	return func(o *metricsPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsPaymentsImpl creates a new metrics decorator instance.
+func NewMetricsPaymentsImpl(ctrl decorators.Payments, opts ...MetricsPaymentsImplOption) *MetricsPaymentsImpl {
+	o := metricsPaymentsImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsPaymentsImpl{delegate: ctrl, isFailure: o.isFailure}
+	deco.vars, _ = expvar.Get("shop_Payments").(*expvar.Map)
+	if deco.vars == nil {
+		deco.vars = expvar.NewMap("shop_Payments")
+	}
+	return deco
+}
+
+// Capture Metrics base method.
+func (t *MetricsPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	t.vars.Add("Capture.calls_total", 1)
+	t.vars.Add("Capture.in_flight", 1)
+	defer t.vars.Add("Capture.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Capture.errors_total", 1)
+			t.vars.Add("Capture.panics_total", 1)
+			t.vars.AddFloat("Capture.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Capture(receipt, ctx)
+	took := time.Since(begin)
+	if ret != nil && t.isFailure(ret) {
+		t.vars.Add("Capture.errors_total", 1)
+	}
+	t.vars.AddFloat("Capture.duration_seconds", took.Seconds())
+	return ret
+}
+
+// Charge Metrics base method.
+func (t *MetricsPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	t.vars.Add("Charge.calls_total", 1)
+	t.vars.Add("Charge.in_flight", 1)
+	defer t.vars.Add("Charge.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Charge.errors_total", 1)
+			t.vars.Add("Charge.panics_total", 1)
+			t.vars.AddFloat("Charge.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	took := time.Since(begin)
+	if ret_2 != nil && t.isFailure(ret_2) {
+		t.vars.Add("Charge.errors_total", 1)
+	}
+	t.vars.AddFloat("Charge.duration_seconds", took.Seconds())
+	return ret, ret_2
+}
+
+// Refund Metrics base method.
+func (t *MetricsPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	t.vars.Add("Refund.calls_total", 1)
+	t.vars.Add("Refund.in_flight", 1)
+	defer t.vars.Add("Refund.in_flight", -1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.vars.Add("Refund.errors_total", 1)
+			t.vars.Add("Refund.panics_total", 1)
+			t.vars.AddFloat("Refund.duration_seconds", took.Seconds())
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Refund(req)
+	took := time.Since(begin)
+	if ret != nil && t.isFailure(ret) {
+		t.vars.Add("Refund.errors_total", 1)
+	}
+	t.vars.AddFloat("Refund.duration_seconds", took.Seconds())
+	return ret
+}
@@ -0,0 +1,71 @@
+package expvarmetrics
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestMetricsStoreImpl(t *testing.T) {
+	ctx := context.Background()
+	s := NewMetricsStoreImpl(decorators.MemStore{})
+	// Constructing a second decorator must reuse the published map.
+	_ = NewMetricsStoreImpl(decorators.MemStore{})
+
+	if err := s.Put(ctx, "a", []byte("1")); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+
+	vars, ok := expvar.Get("shop_Store").(*expvar.Map)
+	if !ok {
+		t.Fatal("shop_Store is not published")
+	}
+	for key, want := range map[string]string{
+		"Put.calls_total":  "1",
+		"Get.calls_total":  "1",
+		"Get.errors_total": "1",
+		"Get.in_flight":    "0",
+	} {
+		v := vars.Get(key)
+		if v == nil {
+			t.Errorf("%s is not published", key)
+			continue
+		}
+		if got := v.String(); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if vars.Get("Get.duration_seconds") == nil {
+		t.Error("Get.duration_seconds is not published")
+	}
+}
+
+func TestMetricsStoreImpl_Panic(t *testing.T) {
+	s := NewMetricsStoreImpl(decorators.PanickingStore{})
+
+	func() {
+		defer func() {
+			if r := recover(); r != "close" {
+				t.Errorf("recovered %v, want the panic of the delegate", r)
+			}
+		}()
+		s.Close()
+	}()
+
+	vars := expvar.Get("shop_Store").(*expvar.Map)
+	for key, want := range map[string]string{
+		"Close.calls_total":  "1",
+		"Close.errors_total": "1",
+		"Close.panics_total": "1",
+		"Close.in_flight":    "0",
+	} {
+		if got := vars.Get(key); got == nil || got.String() != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
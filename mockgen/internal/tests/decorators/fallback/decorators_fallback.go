@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=fallback/decorators_fallback.go -package fallback -implementation_type=fallback
+//
+
+// Package fallback is a generated GoMock package.
+package fallback
+
+import (
+	context "context"
+	time "time"
+
+	fallback "github.com/pableeee/implgen/fallback"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// StoreFallback is a fallback decorator of Store interface.
+type StoreFallback struct {
+	primary   decorators.Store
+	secondary decorators.Store
+	accept    func(err error) bool
+	served    func(method string, served fallback.Backend, err error)
+}
+
+var _ decorators.Store = (*StoreFallback)(nil)
+
+// StoreFallbackOption configures a StoreFallback.
+type StoreFallbackOption func(*storeFallbackOptions)
+
+type storeFallbackOptions struct {
+	accept func(err error) bool
+	served func(method string, served fallback.Backend, err error)
+}
+
+// WithStoreFallbackAccept sets the function reporting whether the secondary
+// of a StoreFallback is called when its primary fails with err.
+// Defaults to fallback.Accept, accepting every error but the ones of a
+// context that is done.
+func WithStoreFallbackAccept(accept func(err error) bool) StoreFallbackOption {
+	return func(o *storeFallbackOptions) {
+		o.accept = accept
+	}
+}
+
+// WithStoreFallbackOnServed sets the function called after every call of a
+// StoreFallback, with the Interface.Method name, the implementation
+// that served it and the error it returned, if any.
+func WithStoreFallbackOnServed(served func(method string, served fallback.Backend, err error)) StoreFallbackOption {
+	return func(o *storeFallbackOptions) {
+		o.served = served
+	}
+}
+
+// NewStoreFallback creates a new fallback decorator instance, calling secondary
+// when primary fails.
+func NewStoreFallback(primary, secondary decorators.Store, opts ...StoreFallbackOption) *StoreFallback {
+	o := storeFallbackOptions{accept: fallback.Accept, served: func(string, fallback.Backend, error) {}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &StoreFallback{
+		primary:   primary,
+		secondary: secondary,
+		accept:    o.accept,
+		served:    o.served,
+	}
+	return deco
+}
+
+// Close fallback base method.
+func (t *StoreFallback) Close() {
+	defer t.served("Store.Close", fallback.Primary, nil)
+	t.primary.Close()
+}
+
+// Get fallback base method.
+func (t *StoreFallback) Get(ctx context.Context, key string) ([]byte, error) {
+	ret, ret_2 := t.primary.Get(ctx, key)
+	if ret_2 == nil || !t.accept(ret_2) {
+		t.served("Store.Get", fallback.Primary, ret_2)
+		return ret, ret_2
+	}
+	ret, ret_2 = t.secondary.Get(ctx, key)
+	t.served("Store.Get", fallback.Secondary, ret_2)
+	return ret, ret_2
+}
+
+// Keys fallback base method.
+func (t *StoreFallback) Keys(prefix string, limit int) []string {
+	defer t.served("Store.Keys", fallback.Primary, nil)
+	return t.primary.Keys(prefix, limit)
+}
+
+// Put fallback base method.
+func (t *StoreFallback) Put(ctx context.Context, key string, value []byte) error {
+	ret := t.primary.Put(ctx, key, value)
+	if ret == nil || !t.accept(ret) {
+		t.served("Store.Put", fallback.Primary, ret)
+		return ret
+	}
+	ret = t.secondary.Put(ctx, key, value)
+	t.served("Store.Put", fallback.Secondary, ret)
+	return ret
+}
+
+// PaymentsFallback is a fallback decorator of Payments interface.
+type PaymentsFallback struct {
+	primary   decorators.Payments
+	secondary decorators.Payments
+	accept    func(err error) bool
+	served    func(method string, served fallback.Backend, err error)
+}
+
+var _ decorators.Payments = (*PaymentsFallback)(nil)
+
+// PaymentsFallbackOption configures a PaymentsFallback.
+type PaymentsFallbackOption func(*paymentsFallbackOptions)
+
+type paymentsFallbackOptions struct {
+	accept func(err error) bool
+	served func(method string, served fallback.Backend, err error)
+}
+
+// WithPaymentsFallbackAccept sets the function reporting whether the secondary
+// of a PaymentsFallback is called when its primary fails with err.
+// Defaults to fallback.Accept, accepting every error but the ones of a
+// context that is done.
+func WithPaymentsFallbackAccept(accept func(err error) bool) PaymentsFallbackOption {
+	return func(o *paymentsFallbackOptions) {
+		o.accept = accept
+	}
+}
+
+// WithPaymentsFallbackOnServed sets the function called after every call of a
+// PaymentsFallback, with the Interface.Method name, the implementation
+// that served it and the error it returned, if any.
+func WithPaymentsFallbackOnServed(served func(method string, served fallback.Backend, err error)) PaymentsFallbackOption {
+	return func(o *paymentsFallbackOptions) {
+		o.served = served
+	}
+}
+
+// NewPaymentsFallback creates a new fallback decorator instance, calling secondary
+// when primary fails.
+func NewPaymentsFallback(primary, secondary decorators.Payments, opts ...PaymentsFallbackOption) *PaymentsFallback {
+	o := paymentsFallbackOptions{accept: fallback.Accept, served: func(string, fallback.Backend, error) {}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &PaymentsFallback{
+		primary:   primary,
+		secondary: secondary,
+		accept:    o.accept,
+		served:    o.served,
+	}
+	return deco
+}
+
+// Capture fallback base method.
+func (t *PaymentsFallback) Capture(receipt string, ctx context.Context) error {
+	ret := t.primary.Capture(receipt, ctx)
+	if ret == nil || !t.accept(ret) {
+		t.served("Payments.Capture", fallback.Primary, ret)
+		return ret
+	}
+	ret = t.secondary.Capture(receipt, ctx)
+	t.served("Payments.Capture", fallback.Secondary, ret)
+	return ret
+}
+
+// Charge fallback base method.
+func (t *PaymentsFallback) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	ret, ret_2 := t.primary.Charge(ctx, account, amount, timeout, token)
+	if ret_2 == nil || !t.accept(ret_2) {
+		t.served("Payments.Charge", fallback.Primary, ret_2)
+		return ret, ret_2
+	}
+	ret, ret_2 = t.secondary.Charge(ctx, account, amount, timeout, token)
+	t.served("Payments.Charge", fallback.Secondary, ret_2)
+	return ret, ret_2
+}
+
+// Refund fallback base method.
+func (t *PaymentsFallback) Refund(req *decorators.RefundRequest) error {
+	ret := t.primary.Refund(req)
+	if ret == nil || !t.accept(ret) {
+		t.served("Payments.Refund", fallback.Primary, ret)
+		return ret
+	}
+	ret = t.secondary.Refund(req)
+	t.served("Payments.Refund", fallback.Secondary, ret)
+	return ret
+}
@@ -0,0 +1,87 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/fallback"
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// served is a call reported to the OnServed option.
+type served struct {
+	method  string
+	backend fallback.Backend
+	err     error
+}
+
+func TestStoreFallback(t *testing.T) {
+	primary := decorators.MemStore{"a": []byte("1")}
+	secondary := decorators.MemStore{"a": []byte("2"), "b": []byte("3")}
+	var calls []served
+	s := NewStoreFallback(primary, secondary, WithStoreFallbackOnServed(func(method string, backend fallback.Backend, err error) {
+		calls = append(calls, served{method, backend, err})
+	}))
+
+	ctx := context.Background()
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1 from the primary", v, err)
+	}
+	if v, err := s.Get(ctx, "b"); err != nil || string(v) != "3" {
+		t.Errorf("Get(b) = %q, %v, want 3 from the secondary", v, err)
+	}
+	if _, err := s.Get(ctx, "c"); err != decorators.ErrNotFound {
+		t.Errorf("Get(c) = %v, want %v from the secondary", err, decorators.ErrNotFound)
+	}
+	// Methods that cannot fail only call the primary.
+	if keys := s.Keys("", 10); len(keys) != 1 {
+		t.Errorf("Keys() = %q, want the keys of the primary", keys)
+	}
+
+	want := []served{
+		{"Store.Get", fallback.Primary, nil},
+		{"Store.Get", fallback.Secondary, nil},
+		{"Store.Get", fallback.Secondary, decorators.ErrNotFound},
+		{"Store.Keys", fallback.Primary, nil},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("served calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("served call %d = %v, want %v", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestStoreFallback_Accept(t *testing.T) {
+	primary := decorators.MemStore{}
+	secondary := decorators.MemStore{"a": []byte("2")}
+	s := NewStoreFallback(primary, secondary, WithStoreFallbackAccept(func(err error) bool {
+		return !errors.Is(err, decorators.ErrNotFound)
+	}))
+	if _, err := s.Get(context.Background(), "a"); err != decorators.ErrNotFound {
+		t.Errorf("Get(a) = %v, want %v from the primary", err, decorators.ErrNotFound)
+	}
+}
+
+// canceledStore is a Store whose Get fails with the error of its context.
+type canceledStore struct {
+	decorators.MemStore
+}
+
+func (canceledStore) Get(ctx context.Context, _ string) ([]byte, error) {
+	return nil, ctx.Err()
+}
+
+func TestStoreFallback_Canceled(t *testing.T) {
+	secondary := decorators.MemStore{"a": []byte("2")}
+	s := NewStoreFallback(canceledStore{}, secondary)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Get(ctx, "a"); err != context.Canceled {
+		t.Errorf("Get(a) = %v, want %v from the primary", err, context.Canceled)
+	}
+}
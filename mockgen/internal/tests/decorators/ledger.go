@@ -0,0 +1,40 @@
+package decorators
+
+import (
+	"fmt"
+	"strings"
+
+	ctxpkg "context"
+)
+
+//go:generate mockgen -source=ledger.go -destination=metrics/ledger_metrics.go -package metrics -implementation_type=metrics
+//go:generate mockgen -source=ledger.go -destination=trace/ledger_trace.go -package trace -implementation_type=trace -trace_span_kind=client
+//go:generate mockgen -source=ledger.go -destination=retry/ledger_retry.go -package retry -implementation_type=retry
+
+// Ledger is an append-only log of entries. It imports the context package
+// under another name and fails with a custom error type.
+type Ledger interface {
+	Append(c ctxpkg.Context, entry string) *LedgerError
+}
+
+// LedgerError is the error returned by Ledger.
+type LedgerError struct {
+	Entry string
+}
+
+func (e *LedgerError) Error() string {
+	return fmt.Sprintf("bad ledger entry %q", e.Entry)
+}
+
+// MemLedger is an in-memory Ledger rejecting empty entries.
+type MemLedger struct {
+	entries []string
+}
+
+func (l *MemLedger) Append(_ ctxpkg.Context, entry string) *LedgerError {
+	if strings.TrimSpace(entry) == "" {
+		return &LedgerError{Entry: entry}
+	}
+	l.entries = append(l.entries, entry)
+	return nil
+}
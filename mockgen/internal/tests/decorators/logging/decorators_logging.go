@@ -0,0 +1,337 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=logging/decorators_logging.go -package logging -implementation_type=logging -attribute_args=Store,Payments -attribute_results=Payments.Charge -attribute_exclude=value,token -logging_success_level=info
+//
+
+// Package logging is a generated GoMock package.
+package logging
+
+import (
+	context "context"
+	slog "log/slog"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// LoggedStoreImpl is a logging decorator of Store interface.
+type LoggedStoreImpl struct {
+	delegate      decorators.Store
+	log           *slog.Logger
+	contextLogger func(ctx context.Context) *slog.Logger
+	successLevel  slog.Level
+	failureLevel  slog.Level
+	isFailure     func(error) bool
+}
+
+var _ decorators.Store = (*LoggedStoreImpl)(nil)
+
+// LoggedStoreImplOption configures a LoggedStoreImpl.
+type LoggedStoreImplOption func(*loggedStoreImplOptions)
+
+type loggedStoreImplOptions struct {
+	logger        *slog.Logger
+	contextLogger func(ctx context.Context) *slog.Logger
+	successLevel  slog.Level
+	failureLevel  slog.Level
+	isFailure     func(err error) bool
+}
+
+// WithLoggedStoreImplLogger sets the logger of a LoggedStoreImpl. Defaults to
+// the default logger at the time of each call.
+func WithLoggedStoreImplLogger(l *slog.Logger) LoggedStoreImplOption {
+	return func(o *loggedStoreImplOptions) {
+		o.logger = l
+	}
+}
+
+// WithLoggedStoreImplContextLogger sets the function returning the logger carried
+// by the context of a call, if any, to a LoggedStoreImpl. The logger
+// is used in place of the one set by WithLoggedStoreImplLogger.
+func WithLoggedStoreImplContextLogger(f func(ctx context.Context) *slog.Logger) LoggedStoreImplOption {
+	return func(o *loggedStoreImplOptions) {
+		o.contextLogger = f
+	}
+}
+
+// WithLoggedStoreImplSuccessLevel sets the level of the calls that succeed.
+func WithLoggedStoreImplSuccessLevel(level slog.Level) LoggedStoreImplOption {
+	return func(o *loggedStoreImplOptions) {
+		o.successLevel = level
+	}
+}
+
+// WithLoggedStoreImplFailureLevel sets the level of the calls that fail.
+func WithLoggedStoreImplFailureLevel(level slog.Level) LoggedStoreImplOption {
+	return func(o *loggedStoreImplOptions) {
+		o.failureLevel = level
+	}
+}
+
+// WithLoggedStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a LoggedStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithLoggedStoreImplErrorClassifier(isFailure func(err error) bool) LoggedStoreImplOption {
+	return func(o *loggedStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewLoggedStoreImpl creates a new logging decorator instance.
+func NewLoggedStoreImpl(ctrl decorators.Store, opts ...LoggedStoreImplOption) *LoggedStoreImpl {
+	o := loggedStoreImplOptions{successLevel: slog.LevelInfo, failureLevel: slog.LevelError, isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &LoggedStoreImpl{
+		delegate:      ctrl,
+		log:           o.logger,
+		contextLogger: o.contextLogger,
+		successLevel:  o.successLevel,
+		failureLevel:  o.failureLevel,
+		isFailure:     o.isFailure,
+	}
+	return deco
+}
+
+// logger returns the logger of a call with the given context.
+func (t *LoggedStoreImpl) logger(ctx context.Context) *slog.Logger {
+	if t.contextLogger != nil {
+		if l := t.contextLogger(ctx); l != nil {
+			return l
+		}
+	}
+	if t.log != nil {
+		return t.log
+	}
+	return slog.Default()
+}
+
+// Close logged base method.
+func (t *LoggedStoreImpl) Close() {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(context.Background()).LogAttrs(context.Background(), t.failureLevel, "Store.Close", slog.String("method", "Close"), slog.Duration("duration", time.Since(begin)), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Close()
+	level := t.successLevel
+	if l := t.logger(context.Background()); l.Enabled(context.Background(), level) {
+		l.LogAttrs(context.Background(), level, "Store.Close", slog.String("method", "Close"), slog.Duration("duration", time.Since(begin)))
+	}
+}
+
+// Get logged base method.
+func (t *LoggedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(ctx).LogAttrs(ctx, t.failureLevel, "Store.Get", slog.String("method", "Get"), slog.Duration("duration", time.Since(begin)), slog.String("key", key), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	level := t.successLevel
+	if ret_2 != nil && t.isFailure(ret_2) {
+		level = t.failureLevel
+	}
+	if l := t.logger(ctx); l.Enabled(ctx, level) {
+		l.LogAttrs(ctx, level, "Store.Get", slog.String("method", "Get"), slog.Duration("duration", time.Since(begin)), slog.String("key", key), slog.Any("error", ret_2))
+	}
+	return ret, ret_2
+}
+
+// Keys logged base method.
+func (t *LoggedStoreImpl) Keys(prefix string, limit int) []string {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(context.Background()).LogAttrs(context.Background(), t.failureLevel, "Store.Keys", slog.String("method", "Keys"), slog.Duration("duration", time.Since(begin)), slog.String("prefix", prefix), slog.Int("limit", limit), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Keys(prefix, limit)
+	level := t.successLevel
+	if l := t.logger(context.Background()); l.Enabled(context.Background(), level) {
+		l.LogAttrs(context.Background(), level, "Store.Keys", slog.String("method", "Keys"), slog.Duration("duration", time.Since(begin)), slog.String("prefix", prefix), slog.Int("limit", limit))
+	}
+	return ret
+}
+
+// Put logged base method.
+func (t *LoggedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(ctx).LogAttrs(ctx, t.failureLevel, "Store.Put", slog.String("method", "Put"), slog.Duration("duration", time.Since(begin)), slog.String("key", key), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Put(ctx, key, value)
+	level := t.successLevel
+	if ret != nil && t.isFailure(ret) {
+		level = t.failureLevel
+	}
+	if l := t.logger(ctx); l.Enabled(ctx, level) {
+		l.LogAttrs(ctx, level, "Store.Put", slog.String("method", "Put"), slog.Duration("duration", time.Since(begin)), slog.String("key", key), slog.Any("error", ret))
+	}
+	return ret
+}
+
+// LoggedPaymentsImpl is a logging decorator of Payments interface.
+type LoggedPaymentsImpl struct {
+	delegate      decorators.Payments
+	log           *slog.Logger
+	contextLogger func(ctx context.Context) *slog.Logger
+	successLevel  slog.Level
+	failureLevel  slog.Level
+	isFailure     func(error) bool
+}
+
+var _ decorators.Payments = (*LoggedPaymentsImpl)(nil)
+
+// LoggedPaymentsImplOption configures a LoggedPaymentsImpl.
+type LoggedPaymentsImplOption func(*loggedPaymentsImplOptions)
+
+type loggedPaymentsImplOptions struct {
+	logger        *slog.Logger
+	contextLogger func(ctx context.Context) *slog.Logger
+	successLevel  slog.Level
+	failureLevel  slog.Level
+	isFailure     func(err error) bool
+}
+
+// WithLoggedPaymentsImplLogger sets the logger of a LoggedPaymentsImpl. Defaults to
+// the default logger at the time of each call.
+func WithLoggedPaymentsImplLogger(l *slog.Logger) LoggedPaymentsImplOption {
+	return func(o *loggedPaymentsImplOptions) {
+		o.logger = l
+	}
+}
+
+// WithLoggedPaymentsImplContextLogger sets the function returning the logger carried
+// by the context of a call, if any, to a LoggedPaymentsImpl. The logger
+// is used in place of the one set by WithLoggedPaymentsImplLogger.
+func WithLoggedPaymentsImplContextLogger(f func(ctx context.Context) *slog.Logger) LoggedPaymentsImplOption {
+	return func(o *loggedPaymentsImplOptions) {
+		o.contextLogger = f
+	}
+}
+
+// WithLoggedPaymentsImplSuccessLevel sets the level of the calls that succeed.
+func WithLoggedPaymentsImplSuccessLevel(level slog.Level) LoggedPaymentsImplOption {
+	return func(o *loggedPaymentsImplOptions) {
+		o.successLevel = level
+	}
+}
+
+// WithLoggedPaymentsImplFailureLevel sets the level of the calls that fail.
+func WithLoggedPaymentsImplFailureLevel(level slog.Level) LoggedPaymentsImplOption {
+	return func(o *loggedPaymentsImplOptions) {
+		o.failureLevel = level
+	}
+}
+
+// WithLoggedPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a LoggedPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithLoggedPaymentsImplErrorClassifier(isFailure func(err error) bool) LoggedPaymentsImplOption {
+	return func(o *loggedPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewLoggedPaymentsImpl creates a new logging decorator instance.
+func NewLoggedPaymentsImpl(ctrl decorators.Payments, opts ...LoggedPaymentsImplOption) *LoggedPaymentsImpl {
+	o := loggedPaymentsImplOptions{successLevel: slog.LevelInfo, failureLevel: slog.LevelError, isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &LoggedPaymentsImpl{
+		delegate:      ctrl,
+		log:           o.logger,
+		contextLogger: o.contextLogger,
+		successLevel:  o.successLevel,
+		failureLevel:  o.failureLevel,
+		isFailure:     o.isFailure,
+	}
+	return deco
+}
+
+// logger returns the logger of a call with the given context.
+func (t *LoggedPaymentsImpl) logger(ctx context.Context) *slog.Logger {
+	if t.contextLogger != nil {
+		if l := t.contextLogger(ctx); l != nil {
+			return l
+		}
+	}
+	if t.log != nil {
+		return t.log
+	}
+	return slog.Default()
+}
+
+// Capture logged base method.
+func (t *LoggedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(ctx).LogAttrs(ctx, t.failureLevel, "Payments.Capture", slog.String("method", "Capture"), slog.Duration("duration", time.Since(begin)), slog.String("receipt", receipt), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Capture(receipt, ctx)
+	level := t.successLevel
+	if ret != nil && t.isFailure(ret) {
+		level = t.failureLevel
+	}
+	if l := t.logger(ctx); l.Enabled(ctx, level) {
+		l.LogAttrs(ctx, level, "Payments.Capture", slog.String("method", "Capture"), slog.Duration("duration", time.Since(begin)), slog.String("receipt", receipt), slog.Any("error", ret))
+	}
+	return ret
+}
+
+// Charge logged base method.
+func (t *LoggedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(ctx).LogAttrs(ctx, t.failureLevel, "Payments.Charge", slog.String("method", "Charge"), slog.Duration("duration", time.Since(begin)), slog.Any("account", account), slog.Int64("amount", int64(amount)), slog.Duration("timeout", timeout), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	level := t.successLevel
+	if ret_2 != nil && t.isFailure(ret_2) {
+		level = t.failureLevel
+	}
+	if l := t.logger(ctx); l.Enabled(ctx, level) {
+		l.LogAttrs(ctx, level, "Payments.Charge", slog.String("method", "Charge"), slog.Duration("duration", time.Since(begin)), slog.Any("account", account), slog.Int64("amount", int64(amount)), slog.Duration("timeout", timeout), slog.Any("error", ret_2), slog.String("receipt", ret))
+	}
+	return ret, ret_2
+}
+
+// Refund logged base method.
+func (t *LoggedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger(context.Background()).LogAttrs(context.Background(), t.failureLevel, "Payments.Refund", slog.String("method", "Refund"), slog.Duration("duration", time.Since(begin)), slog.Any("req", req), slog.Any("panic", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Refund(req)
+	level := t.successLevel
+	if ret != nil && t.isFailure(ret) {
+		level = t.failureLevel
+	}
+	if l := t.logger(context.Background()); l.Enabled(context.Background(), level) {
+		l.LogAttrs(context.Background(), level, "Payments.Refund", slog.String("method", "Refund"), slog.Duration("duration", time.Since(begin)), slog.Any("req", req), slog.Any("error", ret))
+	}
+	return ret
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// records decodes the JSON records written to buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var recs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		rec := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("bad record %q: %v", line, err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func TestLoggedStoreImpl(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s := NewLoggedStoreImpl(decorators.MemStore{}, WithLoggedStoreImplLogger(logger))
+
+	ctx := context.Background()
+	if err := s.Put(ctx, "a", []byte("secret")); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+
+	recs := records(t, &buf)
+	if len(recs) != 2 {
+		t.Fatalf("logged %d records, want 2: %s", len(recs), buf.String())
+	}
+	for i, want := range []map[string]any{
+		{"msg": "Store.Put", "level": "INFO", "method": "Put", "key": "a", "error": nil},
+		{"msg": "Store.Get", "level": "ERROR", "method": "Get", "key": "b", "error": "not found"},
+	} {
+		for k, v := range want {
+			if got := recs[i][k]; got != v {
+				t.Errorf("record %d: %s = %v, want %v", i, k, got, v)
+			}
+		}
+		if _, ok := recs[i]["duration"]; !ok {
+			t.Errorf("record %d has no duration", i)
+		}
+	}
+	if _, ok := recs[0]["value"]; ok {
+		t.Error("excluded argument value was logged")
+	}
+}
+
+type loggerKey struct{}
+
+func TestLoggedStoreImpl_ContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	s := NewLoggedStoreImpl(decorators.MemStore{},
+		WithLoggedStoreImplContextLogger(func(ctx context.Context) *slog.Logger {
+			l, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+			return l
+		}),
+		WithLoggedStoreImplSuccessLevel(slog.LevelDebug),
+		WithLoggedStoreImplErrorClassifier(func(err error) bool {
+			return !errors.Is(err, decorators.ErrNotFound)
+		}))
+
+	ctx := context.WithValue(context.Background(), loggerKey{}, logger.With("request", "r1"))
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("logged %s, want nothing below the info level", buf.String())
+	}
+
+	s = NewLoggedStoreImpl(decorators.MemStore{},
+		WithLoggedStoreImplContextLogger(func(ctx context.Context) *slog.Logger {
+			l, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+			return l
+		}))
+	if err := s.Put(ctx, "a", nil); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	recs := records(t, &buf)
+	if len(recs) != 1 || recs[0]["request"] != "r1" {
+		t.Errorf("logged %v, want a record of the context logger", recs)
+	}
+}
+
+func TestLoggedPaymentsImpl(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	p := NewLoggedPaymentsImpl(decorators.FakePayments{}, WithLoggedPaymentsImplLogger(logger))
+
+	if _, err := p.Charge(context.Background(), decorators.Account{ID: "acc"}, 42, time.Second, "secret"); err != nil {
+		t.Fatalf("Charge() = %v", err)
+	}
+
+	recs := records(t, &buf)
+	if len(recs) != 1 {
+		t.Fatalf("logged %d records, want 1: %s", len(recs), buf.String())
+	}
+	for k, v := range map[string]any{"amount": float64(42), "timeout": float64(time.Second), "receipt": "acc-receipt"} {
+		if got := recs[0][k]; got != v {
+			t.Errorf("%s = %v, want %v", k, got, v)
+		}
+	}
+	if _, ok := recs[0]["token"]; ok {
+		t.Error("excluded argument token was logged")
+	}
+}
+
+func TestLoggedStoreImpl_Panic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	s := NewLoggedStoreImpl(decorators.PanickingStore{}, WithLoggedStoreImplLogger(logger))
+
+	func() {
+		defer func() {
+			if r := recover(); r != "close" {
+				t.Errorf("recovered %v, want the panic of the delegate", r)
+			}
+		}()
+		s.Close()
+	}()
+
+	recs := records(t, &buf)
+	if len(recs) != 1 || recs[0]["level"] != "ERROR" || recs[0]["panic"] != "close" {
+		t.Errorf("logged %v, want the panic at the error level", recs)
+	}
+}
@@ -0,0 +1,74 @@
+package decorators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by MemStore when a key is not present.
+var ErrNotFound = errors.New("not found")
+
+// MemStore is an in-memory Store the decorator tests delegate to.
+type MemStore map[string][]byte
+
+func (s MemStore) Get(_ context.Context, key string) ([]byte, error) {
+	v, ok := s[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func (s MemStore) Put(_ context.Context, key string, value []byte) error {
+	s[key] = value
+	return nil
+}
+
+func (s MemStore) Keys(prefix string, limit int) []string {
+	var keys []string
+	for k := range s {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+func (s MemStore) Close() {}
+
+// FakePayments is a Payments that charges nothing.
+type FakePayments struct{}
+
+func (FakePayments) Charge(_ context.Context, account Account, amount int64, _ time.Duration, _ string) (string, error) {
+	if amount <= 0 {
+		return "", fmt.Errorf("bad amount %d", amount)
+	}
+	return account.ID + "-receipt", nil
+}
+
+func (FakePayments) Capture(string, context.Context) error {
+	return nil
+}
+
+func (FakePayments) Refund(*RefundRequest) error {
+	return nil
+}
+
+// PanickingStore is a Store whose methods panic.
+type PanickingStore struct{}
+
+func (PanickingStore) Get(context.Context, string) ([]byte, error) { panic("get") }
+
+func (PanickingStore) Put(context.Context, string, []byte) error { panic("put") }
+
+func (PanickingStore) Keys(string, int) []string { panic("keys") }
+
+func (PanickingStore) Close() { panic("close") }
@@ -0,0 +1,316 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=metrics/decorators_metrics.go -package metrics -implementation_type=metrics
+//
+
+// Package metrics is a generated GoMock package.
+package metrics
+
+import (
+	context "context"
+	time "time"
+
+	metrics "github.com/go-kit/kit/metrics"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsStoreImpl is a metrics decorator of Store interface.
+type MetricsStoreImpl struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  decorators.Store
+	isFailure func(error) bool
+}
+
+var _ decorators.Store = (*MetricsStoreImpl)(nil)
+
+// MetricsStoreImplOption configures a MetricsStoreImpl.
+type MetricsStoreImplOption func(*metricsStoreImplOptions)
+
+type metricsStoreImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsStoreImplErrorClassifier(isFailure func(err error) bool) MetricsStoreImplOption {
+	return func(o *metricsStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsStoreImpl creates a new metrics decorator instance.
+func NewMetricsStoreImpl(ctrl decorators.Store, opts ...MetricsStoreImplOption) *MetricsStoreImpl {
+	o := metricsStoreImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsStoreImpl{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Store",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Store calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Store",
+		Name:      "calls_total",
+		Help:      "Total number of Store calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Store",
+		Name:      "errors_total",
+		Help:      "Total number of Store calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Store",
+		Name:      "in_flight",
+		Help:      "Number of Store calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Close Metrics base method.
+func (t *MetricsStoreImpl) Close() {
+	t.calls.With("method", "Close").Add(1)
+	inFlight := t.inFlight.With("method", "Close")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Close").Add(1)
+			t.duration.With("method", "Close", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Close()
+	took := time.Since(begin)
+	t.duration.With("method", "Close", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// Get Metrics base method.
+func (t *MetricsStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	t.calls.With("method", "Get").Add(1)
+	inFlight := t.inFlight.With("method", "Get")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Get").Add(1)
+			t.duration.With("method", "Get", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Get").Add(1)
+	}
+	t.duration.With("method", "Get", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Keys Metrics base method.
+func (t *MetricsStoreImpl) Keys(prefix string, limit int) []string {
+	t.calls.With("method", "Keys").Add(1)
+	inFlight := t.inFlight.With("method", "Keys")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Keys").Add(1)
+			t.duration.With("method", "Keys", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Keys(prefix, limit)
+	took := time.Since(begin)
+	t.duration.With("method", "Keys", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Put Metrics base method.
+func (t *MetricsStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	t.calls.With("method", "Put").Add(1)
+	inFlight := t.inFlight.With("method", "Put")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Put").Add(1)
+			t.duration.With("method", "Put", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Put(ctx, key, value)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.With("method", "Put").Add(1)
+	}
+	t.duration.With("method", "Put", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsPaymentsImpl is a metrics decorator of Payments interface.
+type MetricsPaymentsImpl struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  decorators.Payments
+	isFailure func(error) bool
+}
+
+var _ decorators.Payments = (*MetricsPaymentsImpl)(nil)
+
+// MetricsPaymentsImplOption configures a MetricsPaymentsImpl.
+type MetricsPaymentsImplOption func(*metricsPaymentsImplOptions)
+
+type metricsPaymentsImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsPaymentsImplErrorClassifier(isFailure func(err error) bool) MetricsPaymentsImplOption {
+	return func(o *metricsPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsPaymentsImpl creates a new metrics decorator instance.
+func NewMetricsPaymentsImpl(ctrl decorators.Payments, opts ...MetricsPaymentsImplOption) *MetricsPaymentsImpl {
+	o := metricsPaymentsImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsPaymentsImpl{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Payments",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Payments calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Payments",
+		Name:      "calls_total",
+		Help:      "Total number of Payments calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Payments",
+		Name:      "errors_total",
+		Help:      "Total number of Payments calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Payments",
+		Name:      "in_flight",
+		Help:      "Number of Payments calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Capture Metrics base method.
+func (t *MetricsPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	t.calls.With("method", "Capture").Add(1)
+	inFlight := t.inFlight.With("method", "Capture")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Capture").Add(1)
+			t.duration.With("method", "Capture", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Capture(receipt, ctx)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.With("method", "Capture").Add(1)
+	}
+	t.duration.With("method", "Capture", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Charge Metrics base method.
+func (t *MetricsPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	t.calls.With("method", "Charge").Add(1)
+	inFlight := t.inFlight.With("method", "Charge")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Charge").Add(1)
+			t.duration.With("method", "Charge", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Charge").Add(1)
+	}
+	t.duration.With("method", "Charge", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Refund Metrics base method.
+func (t *MetricsPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	t.calls.With("method", "Refund").Add(1)
+	inFlight := t.inFlight.With("method", "Refund")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Refund").Add(1)
+			t.duration.With("method", "Refund", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Refund(req)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.With("method", "Refund").Add(1)
+	}
+	t.duration.With("method", "Refund", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
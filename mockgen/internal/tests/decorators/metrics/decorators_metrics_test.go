@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestMetricsStoreImpl(t *testing.T) {
+	ctx := context.Background()
+	s := NewMetricsStoreImpl(decorators.MemStore{})
+
+	if err := s.Put(ctx, "a", []byte("1")); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if _, err := s.Get(ctx, "a"); err != nil {
+		t.Fatalf("Get(a) = %v", err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		want   float64
+	}{
+		{"Store_calls_total", "Get", 2},
+		{"Store_calls_total", "Put", 1},
+		{"Store_errors_total", "Get", 1},
+		{"Store_in_flight", "Get", 0},
+	} {
+		if got := gather(t, tc.name, tc.method); got != tc.want {
+			t.Errorf("%s{method=%q} = %v, want %v", tc.name, tc.method, got, tc.want)
+		}
+	}
+}
+
+// gather returns the value of the counter or gauge with the given name and
+// method label from the default registry.
+func gather(t *testing.T, name, method string) float64 {
+	t.Helper()
+	mfs, err := stdprometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "method" && l.GetValue() == method {
+					return m.GetCounter().GetValue() + m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	t.Fatalf("metric %s{method=%q} not found", name, method)
+	return 0
+}
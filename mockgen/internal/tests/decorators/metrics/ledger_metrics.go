@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ledger.go -destination=metrics/ledger_metrics.go -package metrics -implementation_type=metrics
+//
+
+// Package metrics is a generated GoMock package.
+package metrics
+
+import (
+	context "context"
+	time "time"
+
+	metrics "github.com/go-kit/kit/metrics"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsLedgerImpl is a metrics decorator of Ledger interface.
+type MetricsLedgerImpl struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  decorators.Ledger
+	isFailure func(error) bool
+}
+
+var _ decorators.Ledger = (*MetricsLedgerImpl)(nil)
+
+// MetricsLedgerImplOption configures a MetricsLedgerImpl.
+type MetricsLedgerImplOption func(*metricsLedgerImplOptions)
+
+type metricsLedgerImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsLedgerImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsLedgerImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsLedgerImplErrorClassifier(isFailure func(err error) bool) MetricsLedgerImplOption {
+	return func(o *metricsLedgerImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsLedgerImpl creates a new metrics decorator instance.
+func NewMetricsLedgerImpl(ctrl decorators.Ledger, opts ...MetricsLedgerImplOption) *MetricsLedgerImpl {
+	o := metricsLedgerImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsLedgerImpl{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Ledger",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Ledger calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Ledger",
+		Name:      "calls_total",
+		Help:      "Total number of Ledger calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Ledger",
+		Name:      "errors_total",
+		Help:      "Total number of Ledger calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Ledger",
+		Name:      "in_flight",
+		Help:      "Number of Ledger calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Append Metrics base method.
+func (t *MetricsLedgerImpl) Append(c context.Context, entry string) *decorators.LedgerError {
+	t.calls.With("method", "Append").Add(1)
+	inFlight := t.inFlight.With("method", "Append")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Append").Add(1)
+			t.duration.With("method", "Append", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Append(c, entry)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Append").Add(1)
+	}
+	t.duration.With("method", "Append", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
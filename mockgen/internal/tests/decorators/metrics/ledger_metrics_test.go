@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestMetricsLedgerImpl(t *testing.T) {
+	ctx := context.Background()
+	l := NewMetricsLedgerImpl(&decorators.MemLedger{})
+
+	if err := l.Append(ctx, "entry"); err != nil {
+		t.Fatalf("Append(entry) = %v", err)
+	}
+	if err := l.Append(ctx, ""); err == nil {
+		t.Fatal("Append() succeeded, want error")
+	}
+
+	if got := gather(t, "Ledger_calls_total", "Append"); got != 2 {
+		t.Errorf("Ledger_calls_total{method=\"Append\"} = %v, want 2", got)
+	}
+	if got := gather(t, "Ledger_errors_total", "Append"); got != 1 {
+		t.Errorf("Ledger_errors_total{method=\"Append\"} = %v, want 1", got)
+	}
+}
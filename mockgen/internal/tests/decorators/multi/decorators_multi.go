@@ -0,0 +1,264 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=multi/decorators_multi.go -package multi -implementation_type=multi
+//
+
+// Package multi is a generated GoMock package.
+package multi
+
+import (
+	context "context"
+	errors "errors"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	multi "github.com/pableeee/implgen/multi"
+)
+
+// MultiStore is a multiplexer of Store interface.
+type MultiStore struct {
+	impls     []decorators.Store
+	parallel  bool
+	strategy  multi.Strategy
+	mergeGet  func(results []MultiStoreGetResult) ([]byte, error)
+	mergeKeys func(results []MultiStoreKeysResult) []string
+}
+
+var _ decorators.Store = (*MultiStore)(nil)
+
+// MultiStoreGetResult holds the results of a call of Get on an implementation
+// of a MultiStore.
+type MultiStoreGetResult struct {
+	R0 []byte
+	R1 error
+}
+
+// MultiStoreKeysResult holds the results of a call of Keys on an implementation
+// of a MultiStore.
+type MultiStoreKeysResult struct {
+	R0 []string
+}
+
+// MultiStoreOption configures a MultiStore.
+type MultiStoreOption func(*multiStoreOptions)
+
+type multiStoreOptions struct {
+	parallel  bool
+	strategy  multi.Strategy
+	mergeGet  func(results []MultiStoreGetResult) ([]byte, error)
+	mergeKeys func(results []MultiStoreKeysResult) []string
+}
+
+// WithMultiStoreParallel sets whether the implementations of a MultiStore
+// are called in parallel, rather than in order. Defaults to in order.
+func WithMultiStoreParallel(parallel bool) MultiStoreOption {
+	return func(o *multiStoreOptions) {
+		o.parallel = parallel
+	}
+}
+
+// WithMultiStoreStrategy sets the strategy selecting the results returned by
+// the methods of a MultiStore with results other than an error.
+// Every implementation is called, whatever the strategy. Defaults to
+// multi.FirstSuccess.
+func WithMultiStoreStrategy(strategy multi.Strategy) MultiStoreOption {
+	return func(o *multiStoreOptions) {
+		o.strategy = strategy
+	}
+}
+
+// WithMultiStoreMergeGet sets the function merging the results of the calls of
+// Get on the implementations of a MultiStore, in their
+// order, in place of its strategy.
+func WithMultiStoreMergeGet(merge func(results []MultiStoreGetResult) ([]byte, error)) MultiStoreOption {
+	return func(o *multiStoreOptions) {
+		o.mergeGet = merge
+	}
+}
+
+// WithMultiStoreMergeKeys sets the function merging the results of the calls of
+// Keys on the implementations of a MultiStore, in their
+// order, in place of its strategy.
+func WithMultiStoreMergeKeys(merge func(results []MultiStoreKeysResult) []string) MultiStoreOption {
+	return func(o *multiStoreOptions) {
+		o.mergeKeys = merge
+	}
+}
+
+// NewMultiStore creates a new multiplexer instance, calling every one of impls.
+func NewMultiStore(impls []decorators.Store, opts ...MultiStoreOption) *MultiStore {
+	o := multiStoreOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MultiStore{
+		impls:     impls,
+		parallel:  o.parallel,
+		strategy:  o.strategy,
+		mergeGet:  o.mergeGet,
+		mergeKeys: o.mergeKeys,
+	}
+	return deco
+}
+
+// Close multiplexer base method.
+func (t *MultiStore) Close() {
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		t.impls[i].Close()
+	})
+}
+
+// Get multiplexer base method.
+func (t *MultiStore) Get(ctx context.Context, key string) ([]byte, error) {
+	results := make([]MultiStoreGetResult, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		results[i].R0, results[i].R1 = t.impls[i].Get(ctx, key)
+	})
+	if t.mergeGet != nil {
+		return t.mergeGet(results)
+	}
+	var errs []error
+	for _, r := range results {
+		if r.R1 == nil || t.strategy == multi.FirstResult {
+			return r.R0, r.R1
+		}
+		errs = append(errs, r.R1)
+	}
+	var ret []byte
+	return ret, errors.Join(errs...)
+}
+
+// Keys multiplexer base method.
+func (t *MultiStore) Keys(prefix string, limit int) []string {
+	results := make([]MultiStoreKeysResult, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		results[i].R0 = t.impls[i].Keys(prefix, limit)
+	})
+	if t.mergeKeys != nil {
+		return t.mergeKeys(results)
+	}
+	if len(results) > 0 {
+		r := results[0]
+		return r.R0
+	}
+	var ret []string
+	return ret
+}
+
+// Put multiplexer base method.
+func (t *MultiStore) Put(ctx context.Context, key string, value []byte) error {
+	errs := make([]error, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		errs[i] = t.impls[i].Put(ctx, key, value)
+	})
+	return errors.Join(errs...)
+}
+
+// MultiPayments is a multiplexer of Payments interface.
+type MultiPayments struct {
+	impls       []decorators.Payments
+	parallel    bool
+	strategy    multi.Strategy
+	mergeCharge func(results []MultiPaymentsChargeResult) (string, error)
+}
+
+var _ decorators.Payments = (*MultiPayments)(nil)
+
+// MultiPaymentsChargeResult holds the results of a call of Charge on an implementation
+// of a MultiPayments.
+type MultiPaymentsChargeResult struct {
+	R0 string
+	R1 error
+}
+
+// MultiPaymentsOption configures a MultiPayments.
+type MultiPaymentsOption func(*multiPaymentsOptions)
+
+type multiPaymentsOptions struct {
+	parallel    bool
+	strategy    multi.Strategy
+	mergeCharge func(results []MultiPaymentsChargeResult) (string, error)
+}
+
+// WithMultiPaymentsParallel sets whether the implementations of a MultiPayments
+// are called in parallel, rather than in order. Defaults to in order.
+func WithMultiPaymentsParallel(parallel bool) MultiPaymentsOption {
+	return func(o *multiPaymentsOptions) {
+		o.parallel = parallel
+	}
+}
+
+// WithMultiPaymentsStrategy sets the strategy selecting the results returned by
+// the methods of a MultiPayments with results other than an error.
+// Every implementation is called, whatever the strategy. Defaults to
+// multi.FirstSuccess.
+func WithMultiPaymentsStrategy(strategy multi.Strategy) MultiPaymentsOption {
+	return func(o *multiPaymentsOptions) {
+		o.strategy = strategy
+	}
+}
+
+// WithMultiPaymentsMergeCharge sets the function merging the results of the calls of
+// Charge on the implementations of a MultiPayments, in their
+// order, in place of its strategy.
+func WithMultiPaymentsMergeCharge(merge func(results []MultiPaymentsChargeResult) (string, error)) MultiPaymentsOption {
+	return func(o *multiPaymentsOptions) {
+		o.mergeCharge = merge
+	}
+}
+
+// NewMultiPayments creates a new multiplexer instance, calling every one of impls.
+func NewMultiPayments(impls []decorators.Payments, opts ...MultiPaymentsOption) *MultiPayments {
+	o := multiPaymentsOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MultiPayments{
+		impls:       impls,
+		parallel:    o.parallel,
+		strategy:    o.strategy,
+		mergeCharge: o.mergeCharge,
+	}
+	return deco
+}
+
+// Capture multiplexer base method.
+func (t *MultiPayments) Capture(receipt string, ctx context.Context) error {
+	errs := make([]error, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		errs[i] = t.impls[i].Capture(receipt, ctx)
+	})
+	return errors.Join(errs...)
+}
+
+// Charge multiplexer base method.
+func (t *MultiPayments) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	results := make([]MultiPaymentsChargeResult, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		results[i].R0, results[i].R1 = t.impls[i].Charge(ctx, account, amount, timeout, token)
+	})
+	if t.mergeCharge != nil {
+		return t.mergeCharge(results)
+	}
+	var errs []error
+	for _, r := range results {
+		if r.R1 == nil || t.strategy == multi.FirstResult {
+			return r.R0, r.R1
+		}
+		errs = append(errs, r.R1)
+	}
+	var ret string
+	return ret, errors.Join(errs...)
+}
+
+// Refund multiplexer base method.
+func (t *MultiPayments) Refund(req *decorators.RefundRequest) error {
+	errs := make([]error, len(t.impls))
+	multi.Dispatch(len(t.impls), t.parallel, func(i int) {
+		errs[i] = t.impls[i].Refund(req)
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/multi"
+)
+
+// failingStore is a Store whose Put fails.
+type failingStore struct {
+	decorators.MemStore
+	err error
+}
+
+func (s failingStore) Put(context.Context, string, []byte) error {
+	return s.err
+}
+
+func TestMultiStore(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		first := decorators.MemStore{"b": []byte("1")}
+		second := decorators.MemStore{"a": []byte("2"), "b": []byte("3")}
+		s := NewMultiStore([]decorators.Store{first, second}, WithMultiStoreParallel(parallel))
+
+		ctx := context.Background()
+		// Put is broadcast.
+		if err := s.Put(ctx, "c", []byte("4")); err != nil {
+			t.Fatal(err)
+		}
+		if string(first["c"]) != "4" || string(second["c"]) != "4" {
+			t.Errorf("Put() stored %q and %q, want 4 in both", first["c"], second["c"])
+		}
+		// Get returns the first success.
+		if v, err := s.Get(ctx, "a"); err != nil || string(v) != "2" {
+			t.Errorf("Get(a) = %q, %v, want 2, nil", v, err)
+		}
+		if v, err := s.Get(ctx, "b"); err != nil || string(v) != "1" {
+			t.Errorf("Get(b) = %q, %v, want 1, nil", v, err)
+		}
+		if _, err := s.Get(ctx, "d"); !errors.Is(err, decorators.ErrNotFound) {
+			t.Errorf("Get(d) = %v, want %v", err, decorators.ErrNotFound)
+		}
+		// Keys returns the first result.
+		if keys := s.Keys("", 10); len(keys) != 2 {
+			t.Errorf("Keys() = %q, want the keys of the first store", keys)
+		}
+	}
+}
+
+func TestMultiStore_Errors(t *testing.T) {
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	s := NewMultiStore([]decorators.Store{failingStore{err: errFirst}, failingStore{err: errSecond}})
+	err := s.Put(context.Background(), "a", nil)
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("Put() = %v, want the errors of both stores joined", err)
+	}
+}
+
+func TestMultiStore_Strategy(t *testing.T) {
+	first := decorators.MemStore{}
+	second := decorators.MemStore{"a": []byte("2")}
+	s := NewMultiStore([]decorators.Store{first, second}, WithMultiStoreStrategy(multi.FirstResult))
+	if _, err := s.Get(context.Background(), "a"); err != decorators.ErrNotFound {
+		t.Errorf("Get(a) = %v, want %v from the first store", err, decorators.ErrNotFound)
+	}
+	// Every store is called, whatever the strategy.
+	counted := &countingStore{Store: second}
+	s = NewMultiStore([]decorators.Store{first, counted}, WithMultiStoreStrategy(multi.FirstResult))
+	_, _ = s.Get(context.Background(), "a")
+	if counted.gets != 1 {
+		t.Errorf("Get(a) called the second store %d times, want 1", counted.gets)
+	}
+
+	s = NewMultiStore([]decorators.Store{first, second}, WithMultiStoreMergeKeys(func(results []MultiStoreKeysResult) []string {
+		var keys []string
+		for _, r := range results {
+			keys = append(keys, r.R0...)
+		}
+		return keys
+	}))
+	if keys := s.Keys("", 10); len(keys) != 1 || keys[0] != "a" {
+		t.Errorf("Keys() = %q, want the merged keys [a]", keys)
+	}
+}
+
+// countingStore is a Store counting the calls of Get.
+type countingStore struct {
+	decorators.Store
+	gets int
+}
+
+func (s *countingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.gets++
+	return s.Store.Get(ctx, key)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sinks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sinks.go -destination=noop/sinks_noop.go -package noop -implementation_type=noop
+//
+
+// Package noop is a generated GoMock package.
+package noop
+
+import (
+	context "context"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// NoopAuditSink is a no-op implementation of AuditSink interface.
+type NoopAuditSink struct {
+	err error
+}
+
+var _ decorators.AuditSink = (*NoopAuditSink)(nil)
+
+// NoopAuditSinkOption configures a NoopAuditSink.
+type NoopAuditSinkOption func(*noopAuditSinkOptions)
+
+type noopAuditSinkOptions struct {
+	err error
+}
+
+// WithNoopAuditSinkError sets the error returned by the methods of a
+// NoopAuditSink with a result of type error. Defaults to nil.
+func WithNoopAuditSinkError(err error) NoopAuditSinkOption {
+	return func(o *noopAuditSinkOptions) {
+		o.err = err
+	}
+}
+
+// NewNoopAuditSink creates a new no-op implementation instance.
+func NewNoopAuditSink(opts ...NoopAuditSinkOption) *NoopAuditSink {
+	o := noopAuditSinkOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &NoopAuditSink{err: o.err}
+}
+
+// Events no-op base method.
+func (t *NoopAuditSink) Events(ctx context.Context) (<-chan string, error) {
+	ret := make(chan string)
+	close(ret)
+	return ret, t.err
+}
+
+// Flush no-op base method.
+func (t *NoopAuditSink) Flush() {
+}
+
+// Record no-op base method.
+func (t *NoopAuditSink) Record(ctx context.Context, event string) error {
+	return t.err
+}
+
+// NoopQueue is a no-op implementation of Queue interface.
+type NoopQueue[T any] struct {
+	err error
+}
+
+// NoopQueueOption configures a NoopQueue.
+type NoopQueueOption func(*noopQueueOptions)
+
+type noopQueueOptions struct {
+	err error
+}
+
+// WithNoopQueueError sets the error returned by the methods of a
+// NoopQueue with a result of type error. Defaults to nil.
+func WithNoopQueueError(err error) NoopQueueOption {
+	return func(o *noopQueueOptions) {
+		o.err = err
+	}
+}
+
+// NewNoopQueue creates a new no-op implementation instance.
+func NewNoopQueue[T any](opts ...NoopQueueOption) *NoopQueue[T] {
+	o := noopQueueOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &NoopQueue[T]{err: o.err}
+}
+
+// Drain no-op base method.
+func (t *NoopQueue[T]) Drain() <-chan T {
+	ret := make(chan T)
+	close(ret)
+	return ret
+}
+
+// Pop no-op base method.
+func (t *NoopQueue[T]) Pop() (T, bool) {
+	var ret T
+	var ret_2 bool
+	return ret, ret_2
+}
+
+// Push no-op base method.
+func (t *NoopQueue[T]) Push(v T) error {
+	return t.err
+}
@@ -0,0 +1,44 @@
+package noop
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestNoopAuditSink(t *testing.T) {
+	var s decorators.AuditSink = NewNoopAuditSink()
+	ctx := context.Background()
+	if err := s.Record(ctx, "login"); err != nil {
+		t.Errorf("Record() = %v, want nil", err)
+	}
+	events, err := s.Events(ctx)
+	if err != nil {
+		t.Fatalf("Events() = %v, want nil", err)
+	}
+	if _, ok := <-events; ok {
+		t.Error("Events() returned a channel that is not closed")
+	}
+	s.Flush()
+
+	errDisabled := errors.New("audit disabled")
+	s = NewNoopAuditSink(WithNoopAuditSinkError(errDisabled))
+	if err := s.Record(ctx, "login"); err != errDisabled {
+		t.Errorf("Record() = %v, want %v", err, errDisabled)
+	}
+}
+
+func TestNoopQueue(t *testing.T) {
+	var q decorators.Queue[int] = NewNoopQueue[int]()
+	if err := q.Push(1); err != nil {
+		t.Errorf("Push() = %v, want nil", err)
+	}
+	if v, ok := q.Pop(); v != 0 || ok {
+		t.Errorf("Pop() = %v, %v, want 0, false", v, ok)
+	}
+	for range q.Drain() {
+		t.Error("Drain() returned a channel with values")
+	}
+}
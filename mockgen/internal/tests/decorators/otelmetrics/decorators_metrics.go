@@ -0,0 +1,302 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=otelmetrics/decorators_metrics.go -package otelmetrics -implementation_type=metrics -metrics_backend=otel -metrics_unit=milliseconds
+//
+
+// Package otelmetrics is a generated GoMock package.
+package otelmetrics
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	otel "go.opentelemetry.io/otel"
+	attribute "go.opentelemetry.io/otel/attribute"
+	metric "go.opentelemetry.io/otel/metric"
+)
+
+// MetricsStoreImpl is a metrics decorator of Store interface.
+type MetricsStoreImpl struct {
+	duration  metric.Float64Histogram
+	calls     metric.Int64Counter
+	errors    metric.Int64Counter
+	inFlight  metric.Int64UpDownCounter
+	delegate  decorators.Store
+	isFailure func(error) bool
+}
+
+var _ decorators.Store = (*MetricsStoreImpl)(nil)
+
+// MetricsStoreImplOption configures a MetricsStoreImpl.
+type MetricsStoreImplOption func(*metricsStoreImplOptions)
+
+type metricsStoreImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsStoreImplErrorClassifier(isFailure func(err error) bool) MetricsStoreImplOption {
+	return func(o *metricsStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsStoreImpl creates a new metrics decorator instance.
+func NewMetricsStoreImpl(ctrl decorators.Store, opts ...MetricsStoreImplOption) *MetricsStoreImpl {
+	o := metricsStoreImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsStoreImpl{delegate: ctrl, isFailure: o.isFailure}
+	meter := otel.Meter("github.com/pableeee/implgen/mockgen/internal/tests/decorators")
+	var err error
+	if deco.duration, err = meter.Float64Histogram("Store_duration_milliseconds", metric.WithDescription("Time spent in Store calls, in milliseconds."), metric.WithUnit("ms"), metric.WithExplicitBucketBoundaries(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000)); err != nil {
+		otel.Handle(err)
+	}
+	if deco.calls, err = meter.Int64Counter("Store_calls_total", metric.WithDescription("Total number of Store calls.")); err != nil {
+		otel.Handle(err)
+	}
+	if deco.errors, err = meter.Int64Counter("Store_errors_total", metric.WithDescription("Total number of Store calls that returned an error.")); err != nil {
+		otel.Handle(err)
+	}
+	if deco.inFlight, err = meter.Int64UpDownCounter("Store_in_flight", metric.WithDescription("Number of Store calls currently in flight.")); err != nil {
+		otel.Handle(err)
+	}
+	return deco
+}
+
+// Close Metrics base method.
+func (t *MetricsStoreImpl) Close() {
+	attrs := metric.WithAttributes(attribute.String("method", "Close"))
+	t.calls.Add(context.Background(), 1, attrs)
+	t.inFlight.Add(context.Background(), 1, attrs)
+	defer t.inFlight.Add(context.Background(), -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(context.Background(), 1, attrs)
+			t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Close"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Close()
+	took := time.Since(begin)
+	t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Close"), attribute.String("error", failed), attribute.String("panic", "false")))
+}
+
+// Get Metrics base method.
+func (t *MetricsStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	attrs := metric.WithAttributes(attribute.String("method", "Get"))
+	t.calls.Add(ctx, 1, attrs)
+	t.inFlight.Add(ctx, 1, attrs)
+	defer t.inFlight.Add(ctx, -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(ctx, 1, attrs)
+			t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Get"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.Add(ctx, 1, attrs)
+	}
+	t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Get"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret, ret_2
+}
+
+// Keys Metrics base method.
+func (t *MetricsStoreImpl) Keys(prefix string, limit int) []string {
+	attrs := metric.WithAttributes(attribute.String("method", "Keys"))
+	t.calls.Add(context.Background(), 1, attrs)
+	t.inFlight.Add(context.Background(), 1, attrs)
+	defer t.inFlight.Add(context.Background(), -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(context.Background(), 1, attrs)
+			t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Keys"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Keys(prefix, limit)
+	took := time.Since(begin)
+	t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Keys"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret
+}
+
+// Put Metrics base method.
+func (t *MetricsStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	attrs := metric.WithAttributes(attribute.String("method", "Put"))
+	t.calls.Add(ctx, 1, attrs)
+	t.inFlight.Add(ctx, 1, attrs)
+	defer t.inFlight.Add(ctx, -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(ctx, 1, attrs)
+			t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Put"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Put(ctx, key, value)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.Add(ctx, 1, attrs)
+	}
+	t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Put"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret
+}
+
+// MetricsPaymentsImpl is a metrics decorator of Payments interface.
+type MetricsPaymentsImpl struct {
+	duration  metric.Float64Histogram
+	calls     metric.Int64Counter
+	errors    metric.Int64Counter
+	inFlight  metric.Int64UpDownCounter
+	delegate  decorators.Payments
+	isFailure func(error) bool
+}
+
+var _ decorators.Payments = (*MetricsPaymentsImpl)(nil)
+
+// MetricsPaymentsImplOption configures a MetricsPaymentsImpl.
+type MetricsPaymentsImplOption func(*metricsPaymentsImplOptions)
+
+type metricsPaymentsImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsPaymentsImplErrorClassifier(isFailure func(err error) bool) MetricsPaymentsImplOption {
+	return func(o *metricsPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsPaymentsImpl creates a new metrics decorator instance.
+func NewMetricsPaymentsImpl(ctrl decorators.Payments, opts ...MetricsPaymentsImplOption) *MetricsPaymentsImpl {
+	o := metricsPaymentsImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsPaymentsImpl{delegate: ctrl, isFailure: o.isFailure}
+	meter := otel.Meter("github.com/pableeee/implgen/mockgen/internal/tests/decorators")
+	var err error
+	if deco.duration, err = meter.Float64Histogram("Payments_duration_milliseconds", metric.WithDescription("Time spent in Payments calls, in milliseconds."), metric.WithUnit("ms"), metric.WithExplicitBucketBoundaries(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000)); err != nil {
+		otel.Handle(err)
+	}
+	if deco.calls, err = meter.Int64Counter("Payments_calls_total", metric.WithDescription("Total number of Payments calls.")); err != nil {
+		otel.Handle(err)
+	}
+	if deco.errors, err = meter.Int64Counter("Payments_errors_total", metric.WithDescription("Total number of Payments calls that returned an error.")); err != nil {
+		otel.Handle(err)
+	}
+	if deco.inFlight, err = meter.Int64UpDownCounter("Payments_in_flight", metric.WithDescription("Number of Payments calls currently in flight.")); err != nil {
+		otel.Handle(err)
+	}
+	return deco
+}
+
+// Capture Metrics base method.
+func (t *MetricsPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	attrs := metric.WithAttributes(attribute.String("method", "Capture"))
+	t.calls.Add(ctx, 1, attrs)
+	t.inFlight.Add(ctx, 1, attrs)
+	defer t.inFlight.Add(ctx, -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(ctx, 1, attrs)
+			t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Capture"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Capture(receipt, ctx)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.Add(ctx, 1, attrs)
+	}
+	t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Capture"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret
+}
+
+// Charge Metrics base method.
+func (t *MetricsPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	attrs := metric.WithAttributes(attribute.String("method", "Charge"))
+	t.calls.Add(ctx, 1, attrs)
+	t.inFlight.Add(ctx, 1, attrs)
+	defer t.inFlight.Add(ctx, -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(ctx, 1, attrs)
+			t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Charge"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.Add(ctx, 1, attrs)
+	}
+	t.duration.Record(ctx, float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Charge"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret, ret_2
+}
+
+// Refund Metrics base method.
+func (t *MetricsPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	attrs := metric.WithAttributes(attribute.String("method", "Refund"))
+	t.calls.Add(context.Background(), 1, attrs)
+	t.inFlight.Add(context.Background(), 1, attrs)
+	defer t.inFlight.Add(context.Background(), -1, attrs)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.Add(context.Background(), 1, attrs)
+			t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Refund"), attribute.String("error", "true"), attribute.String("panic", "true")))
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Refund(req)
+	took := time.Since(begin)
+	failed = "false"
+	if ret != nil && t.isFailure(ret) {
+		failed = "true"
+		t.errors.Add(context.Background(), 1, attrs)
+	}
+	t.duration.Record(context.Background(), float64(took)/float64(time.Millisecond), metric.WithAttributes(attribute.String("method", "Refund"), attribute.String("error", failed), attribute.String("panic", "false")))
+	return ret
+}
@@ -0,0 +1,103 @@
+package otelmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestMetricsStoreImpl(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	s := NewMetricsStoreImpl(decorators.MemStore{})
+
+	if err := s.Put(ctx, "a", []byte("1")); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+	s.Keys("", 10)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	var durations uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					method, _ := dp.Attributes.Value(attribute.Key("method"))
+					sums[m.Name+"/"+method.AsString()] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				if m.Unit != "ms" {
+					t.Errorf("%s unit = %q, want ms", m.Name, m.Unit)
+				}
+				for _, dp := range data.DataPoints {
+					durations += dp.Count
+				}
+			}
+		}
+	}
+
+	for key, want := range map[string]int64{
+		"Store_calls_total/Put":  1,
+		"Store_calls_total/Get":  1,
+		"Store_calls_total/Keys": 1,
+		"Store_errors_total/Get": 1,
+		"Store_in_flight/Get":    0,
+	} {
+		if got := sums[key]; got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if durations != 3 {
+		t.Errorf("recorded %v durations, want 3", durations)
+	}
+}
+
+func TestMetricsStoreImpl_ErrorClassifier(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	s := NewMetricsStoreImpl(decorators.MemStore{}, WithMetricsStoreImplErrorClassifier(func(err error) bool {
+		return !errors.Is(err, decorators.ErrNotFound)
+	}))
+
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Fatal("Get(b) succeeded, want error")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				if m.Name == "Store_errors_total" && len(data.DataPoints) > 0 {
+					t.Errorf("%s recorded %v, want nothing", m.Name, data.DataPoints)
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					if failed, _ := dp.Attributes.Value("error"); failed.AsString() != "false" {
+						t.Errorf("%s recorded error=%v, want false", m.Name, failed.AsString())
+					}
+				}
+			}
+		}
+	}
+}
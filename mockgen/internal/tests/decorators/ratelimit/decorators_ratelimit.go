@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=ratelimit/decorators_ratelimit.go -package ratelimit -implementation_type=ratelimit
+//
+
+// Package ratelimit is a generated GoMock package.
+package ratelimit
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	ratelimit "github.com/pableeee/implgen/ratelimit"
+)
+
+// LimitedStoreImpl is a rate limiting decorator of Store interface.
+type LimitedStoreImpl struct {
+	delegate decorators.Store
+	limiters map[string]*ratelimit.Limiter
+}
+
+var _ decorators.Store = (*LimitedStoreImpl)(nil)
+
+// LimitedStoreImplOption configures a LimitedStoreImpl.
+type LimitedStoreImplOption func(*limitedStoreImplOptions)
+
+type limitedStoreImplOptions struct {
+	methodLimits map[string]ratelimit.Limits
+}
+
+// WithLimitedStoreImplMethodLimits sets the limits of the methods of a LimitedStoreImpl,
+// by method name, in place of the limits passed to its constructor.
+func WithLimitedStoreImplMethodLimits(limits map[string]ratelimit.Limits) LimitedStoreImplOption {
+	return func(o *limitedStoreImplOptions) {
+		o.methodLimits = limits
+	}
+}
+
+// NewLimitedStoreImpl creates a new rate limiting decorator instance, limiting the
+// calls of every method with limits.
+func NewLimitedStoreImpl(ctrl decorators.Store, limits ratelimit.Limits, opts ...LimitedStoreImplOption) *LimitedStoreImpl {
+	o := limitedStoreImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &LimitedStoreImpl{
+		delegate: ctrl,
+		limiters: ratelimit.NewMethods(limits, o.methodLimits, "Store", "Get", "Put"),
+	}
+	return deco
+}
+
+// Close rate limiting base method.
+func (t *LimitedStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get rate limiting base method.
+func (t *LimitedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	release, err := t.limiters["Get"].Acquire(ctx)
+	if err != nil {
+		var ret []byte
+		return ret, err
+	}
+	defer release()
+	return t.delegate.Get(ctx, key)
+}
+
+// Keys rate limiting base method.
+func (t *LimitedStoreImpl) Keys(prefix string, limit int) []string {
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put rate limiting base method.
+func (t *LimitedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	release, err := t.limiters["Put"].Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return t.delegate.Put(ctx, key, value)
+}
+
+// LimitedPaymentsImpl is a rate limiting decorator of Payments interface.
+type LimitedPaymentsImpl struct {
+	delegate decorators.Payments
+	limiters map[string]*ratelimit.Limiter
+}
+
+var _ decorators.Payments = (*LimitedPaymentsImpl)(nil)
+
+// LimitedPaymentsImplOption configures a LimitedPaymentsImpl.
+type LimitedPaymentsImplOption func(*limitedPaymentsImplOptions)
+
+type limitedPaymentsImplOptions struct {
+	methodLimits map[string]ratelimit.Limits
+}
+
+// WithLimitedPaymentsImplMethodLimits sets the limits of the methods of a LimitedPaymentsImpl,
+// by method name, in place of the limits passed to its constructor.
+func WithLimitedPaymentsImplMethodLimits(limits map[string]ratelimit.Limits) LimitedPaymentsImplOption {
+	return func(o *limitedPaymentsImplOptions) {
+		o.methodLimits = limits
+	}
+}
+
+// NewLimitedPaymentsImpl creates a new rate limiting decorator instance, limiting the
+// calls of every method with limits.
+func NewLimitedPaymentsImpl(ctrl decorators.Payments, limits ratelimit.Limits, opts ...LimitedPaymentsImplOption) *LimitedPaymentsImpl {
+	o := limitedPaymentsImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &LimitedPaymentsImpl{
+		delegate: ctrl,
+		limiters: ratelimit.NewMethods(limits, o.methodLimits, "Payments", "Capture", "Charge", "Refund"),
+	}
+	return deco
+}
+
+// Capture rate limiting base method.
+func (t *LimitedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	release, err := t.limiters["Capture"].Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return t.delegate.Capture(receipt, ctx)
+}
+
+// Charge rate limiting base method.
+func (t *LimitedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	release, err := t.limiters["Charge"].Acquire(ctx)
+	if err != nil {
+		var ret string
+		return ret, err
+	}
+	defer release()
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund rate limiting base method.
+func (t *LimitedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	release, err := t.limiters["Refund"].TryAcquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return t.delegate.Refund(req)
+}
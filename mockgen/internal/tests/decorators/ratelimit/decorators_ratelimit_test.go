@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/ratelimit"
+)
+
+// blockingPayments is a Payments whose Capture calls block until unblocked.
+type blockingPayments struct {
+	decorators.FakePayments
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (p blockingPayments) Capture(string, context.Context) error {
+	p.started <- struct{}{}
+	<-p.unblock
+	return nil
+}
+
+func TestLimitedStoreImpl(t *testing.T) {
+	s := NewLimitedStoreImpl(decorators.MemStore{}, ratelimit.Limits{Rate: 1},
+		WithLimitedStoreImplMethodLimits(map[string]ratelimit.Limits{"Get": {Rate: 100, Burst: 2}}))
+
+	ctx := context.Background()
+	if err := s.Put(ctx, "a", []byte("1")); err != nil {
+		t.Fatalf("Put(a) = %v", err)
+	}
+	// Put waits on its context for the next token.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err := s.Put(ctx, "a", []byte("2"))
+	if !errors.Is(err, ratelimit.ErrLimited) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Put(a) = %v, want a limit error wrapping %v", err, context.DeadlineExceeded)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Get(context.Background(), "a"); err != nil {
+			t.Errorf("Get(a) #%d = %v", i, err)
+		}
+	}
+}
+
+func TestLimitedPaymentsImpl_Concurrency(t *testing.T) {
+	payments := blockingPayments{started: make(chan struct{}), unblock: make(chan struct{})}
+	p := NewLimitedPaymentsImpl(payments, ratelimit.Limits{MaxConcurrent: 1})
+
+	done := make(chan error)
+	go func() { done <- p.Capture("r", context.Background()) }()
+	<-payments.started
+
+	// Refund has no context and fails fast, Capture waits on its context.
+	if err := p.Refund(&decorators.RefundRequest{}); err != nil {
+		t.Errorf("Refund() = %v, want nil as its limits are separate", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var limitErr *ratelimit.LimitError
+	if err := p.Capture("r", ctx); !errors.As(err, &limitErr) || limitErr.Reason != ratelimit.ReasonConcurrency {
+		t.Errorf("Capture() = %v, want a concurrency limit error", err)
+	}
+
+	close(payments.unblock)
+	if err := <-done; err != nil {
+		t.Errorf("Capture() = %v", err)
+	}
+}
+
+func TestLimitedPaymentsImpl_FailFast(t *testing.T) {
+	p := NewLimitedPaymentsImpl(decorators.FakePayments{}, ratelimit.Limits{Rate: 1})
+
+	if err := p.Refund(&decorators.RefundRequest{}); err != nil {
+		t.Fatalf("Refund() = %v", err)
+	}
+	begin := time.Now()
+	if err := p.Refund(&decorators.RefundRequest{}); !errors.Is(err, ratelimit.ErrLimited) {
+		t.Errorf("Refund() = %v, want %v", err, ratelimit.ErrLimited)
+	}
+	if elapsed := time.Since(begin); elapsed > 100*time.Millisecond {
+		t.Errorf("Refund() waited %v, want it to fail fast", elapsed)
+	}
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=record/decorators_record.go -package record -implementation_type=record
+//
+
+// Package record is a generated GoMock package.
+package record
+
+import (
+	context "context"
+	io "io"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	record "github.com/pableeee/implgen/record"
+)
+
+// RecordingStoreImpl is a recording decorator of Store interface.
+type RecordingStoreImpl struct {
+	delegate decorators.Store
+	recorder *record.Recorder
+}
+
+var _ decorators.Store = (*RecordingStoreImpl)(nil)
+
+// NewRecordingStoreImpl creates a new recording decorator instance, recording the
+// calls with recorder. Defaults to discarding them if recorder is nil.
+func NewRecordingStoreImpl(ctrl decorators.Store, recorder *record.Recorder) *RecordingStoreImpl {
+	if recorder == nil {
+		recorder = record.NewRecorder(io.Discard, nil)
+	}
+	return &RecordingStoreImpl{delegate: ctrl, recorder: recorder}
+}
+
+// Close recording base method.
+func (t *RecordingStoreImpl) Close() {
+	t.delegate.Close()
+	t.recorder.Record("Store.Close", []any{}, []any{}, nil)
+}
+
+// Get recording base method.
+func (t *RecordingStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	t.recorder.Record("Store.Get", []any{key}, []any{ret}, ret_2)
+	return ret, ret_2
+}
+
+// Keys recording base method.
+func (t *RecordingStoreImpl) Keys(prefix string, limit int) []string {
+	ret := t.delegate.Keys(prefix, limit)
+	t.recorder.Record("Store.Keys", []any{prefix, limit}, []any{ret}, nil)
+	return ret
+}
+
+// Put recording base method.
+func (t *RecordingStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	ret := t.delegate.Put(ctx, key, value)
+	t.recorder.Record("Store.Put", []any{key, value}, []any{}, ret)
+	return ret
+}
+
+// ReplayStore replays the calls of Store interface recorded by a
+// RecordingStoreImpl.
+type ReplayStore struct {
+	replayer *record.Replayer
+}
+
+var _ decorators.Store = (*ReplayStore)(nil)
+
+// NewReplayStore creates a new replay instance, replaying the calls with
+// replayer.
+func NewReplayStore(replayer *record.Replayer) *ReplayStore {
+	return &ReplayStore{replayer: replayer}
+}
+
+// Close replay base method.
+func (t *ReplayStore) Close() {
+	if err := t.replayer.Replay("Store.Close", []any{}); err != nil {
+		panic(err)
+	}
+}
+
+// Get replay base method.
+func (t *ReplayStore) Get(ctx context.Context, key string) ([]byte, error) {
+	var ret []byte
+	err := t.replayer.Replay("Store.Get", []any{key}, &ret)
+	return ret, err
+}
+
+// Keys replay base method.
+func (t *ReplayStore) Keys(prefix string, limit int) []string {
+	var ret []string
+	if err := t.replayer.Replay("Store.Keys", []any{prefix, limit}, &ret); err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// Put replay base method.
+func (t *ReplayStore) Put(ctx context.Context, key string, value []byte) error {
+	err := t.replayer.Replay("Store.Put", []any{key, value})
+	return err
+}
+
+// RecordingPaymentsImpl is a recording decorator of Payments interface.
+type RecordingPaymentsImpl struct {
+	delegate decorators.Payments
+	recorder *record.Recorder
+}
+
+var _ decorators.Payments = (*RecordingPaymentsImpl)(nil)
+
+// NewRecordingPaymentsImpl creates a new recording decorator instance, recording the
+// calls with recorder. Defaults to discarding them if recorder is nil.
+func NewRecordingPaymentsImpl(ctrl decorators.Payments, recorder *record.Recorder) *RecordingPaymentsImpl {
+	if recorder == nil {
+		recorder = record.NewRecorder(io.Discard, nil)
+	}
+	return &RecordingPaymentsImpl{delegate: ctrl, recorder: recorder}
+}
+
+// Capture recording base method.
+func (t *RecordingPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	ret := t.delegate.Capture(receipt, ctx)
+	t.recorder.Record("Payments.Capture", []any{receipt}, []any{}, ret)
+	return ret
+}
+
+// Charge recording base method.
+func (t *RecordingPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	t.recorder.Record("Payments.Charge", []any{account, amount, timeout, token}, []any{ret}, ret_2)
+	return ret, ret_2
+}
+
+// Refund recording base method.
+func (t *RecordingPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	ret := t.delegate.Refund(req)
+	t.recorder.Record("Payments.Refund", []any{req}, []any{}, ret)
+	return ret
+}
+
+// ReplayPayments replays the calls of Payments interface recorded by a
+// RecordingPaymentsImpl.
+type ReplayPayments struct {
+	replayer *record.Replayer
+}
+
+var _ decorators.Payments = (*ReplayPayments)(nil)
+
+// NewReplayPayments creates a new replay instance, replaying the calls with
+// replayer.
+func NewReplayPayments(replayer *record.Replayer) *ReplayPayments {
+	return &ReplayPayments{replayer: replayer}
+}
+
+// Capture replay base method.
+func (t *ReplayPayments) Capture(receipt string, ctx context.Context) error {
+	err := t.replayer.Replay("Payments.Capture", []any{receipt})
+	return err
+}
+
+// Charge replay base method.
+func (t *ReplayPayments) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	var ret string
+	err := t.replayer.Replay("Payments.Charge", []any{account, amount, timeout, token}, &ret)
+	return ret, err
+}
+
+// Refund replay base method.
+func (t *ReplayPayments) Refund(req *decorators.RefundRequest) error {
+	err := t.replayer.Replay("Payments.Refund", []any{req})
+	return err
+}
@@ -0,0 +1,106 @@
+package record
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/record"
+)
+
+const golden = "testdata/store.jsonl"
+
+func TestRecordingStoreImpl(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := record.NewRecorder(&buf, nil)
+	s := NewRecordingStoreImpl(decorators.MemStore{"a": []byte("1")}, recorder)
+
+	ctx := context.Background()
+	_, _ = s.Get(ctx, "a")
+	_, _ = s.Get(ctx, "b")
+	_ = s.Put(ctx, "b", []byte("2"))
+	_ = s.Keys("", 10)
+	s.Close()
+	if err := recorder.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("recording =\n%s\nwant\n%s", buf.Bytes(), want)
+	}
+}
+
+func TestReplayStore(t *testing.T) {
+	f, err := os.Open(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	replayer, err := record.NewReplayer(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewReplayStore(replayer)
+
+	ctx := context.Background()
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil || err.Error() != decorators.ErrNotFound.Error() {
+		t.Errorf("Get(b) = %v, want the recorded %v", err, decorators.ErrNotFound)
+	}
+	if err := s.Put(ctx, "b", []byte("2")); err != nil {
+		t.Errorf("Put() = %v, want nil", err)
+	}
+	if keys := s.Keys("", 10); len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("Keys() = %q, want [a b]", keys)
+	}
+	s.Close()
+	if n := replayer.Remaining(); n != 0 {
+		t.Errorf("Remaining() = %d, want 0", n)
+	}
+
+	// The calls whose arguments are not the recorded ones fail.
+	var replayErr *record.ReplayError
+	if _, err := s.Get(ctx, "c"); !errors.As(err, &replayErr) {
+		t.Errorf("Get(c) = %v, want a *record.ReplayError", err)
+	}
+}
+
+func TestReplayStore_Mismatch(t *testing.T) {
+	f, err := os.Open(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	replayer, err := record.NewReplayer(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewReplayStore(replayer)
+
+	var replayErr *record.ReplayError
+	if _, err := s.Get(context.Background(), "b"); !errors.As(err, &replayErr) || replayErr.Call != 0 {
+		t.Errorf("Get(b) = %v, want a *record.ReplayError of call 0", err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Keys() with other arguments did not panic")
+		}
+	}()
+	_ = s.Keys("a", 10)
+}
+
+func TestRecordingStoreImpl_NilRecorder(t *testing.T) {
+	s := NewRecordingStoreImpl(decorators.MemStore{"a": []byte("1")}, nil)
+	if v, err := s.Get(context.Background(), "a"); string(v) != "1" || err != nil {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=retry/decorators_retry.go -package retry -implementation_type=retry -retry_non_idempotent=Payments.Refund
+//
+
+// Package retry is a generated GoMock package.
+package retry
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	retry "github.com/pableeee/implgen/retry"
+)
+
+// RetryingStoreImpl is a retry decorator of Store interface.
+type RetryingStoreImpl struct {
+	delegate decorators.Store
+	policy   retry.Policy
+}
+
+var _ decorators.Store = (*RetryingStoreImpl)(nil)
+
+// NewRetryingStoreImpl creates a new retry decorator instance, retrying the
+// failed calls according to policy. Defaults to retry.Backoff{} if policy is nil.
+func NewRetryingStoreImpl(ctrl decorators.Store, policy retry.Policy) *RetryingStoreImpl {
+	if policy == nil {
+		policy = retry.Backoff{}
+	}
+	deco := &RetryingStoreImpl{
+		delegate: ctrl,
+		policy:   policy,
+	}
+	return deco
+}
+
+// Close retrying base method.
+func (t *RetryingStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get retrying base method.
+func (t *RetryingStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	var ret []byte
+	var ret_2 error
+	_ = retry.Do(ctx, t.policy, func() error {
+		ret, ret_2 = t.delegate.Get(ctx, key)
+		return ret_2
+	})
+	return ret, ret_2
+}
+
+// Keys retrying base method.
+func (t *RetryingStoreImpl) Keys(prefix string, limit int) []string {
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put retrying base method.
+func (t *RetryingStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	var ret error
+	_ = retry.Do(ctx, t.policy, func() error {
+		ret = t.delegate.Put(ctx, key, value)
+		return ret
+	})
+	return ret
+}
+
+// RetryingPaymentsImpl is a retry decorator of Payments interface.
+type RetryingPaymentsImpl struct {
+	delegate decorators.Payments
+	policy   retry.Policy
+}
+
+var _ decorators.Payments = (*RetryingPaymentsImpl)(nil)
+
+// NewRetryingPaymentsImpl creates a new retry decorator instance, retrying the
+// failed calls according to policy. Defaults to retry.Backoff{} if policy is nil.
+func NewRetryingPaymentsImpl(ctrl decorators.Payments, policy retry.Policy) *RetryingPaymentsImpl {
+	if policy == nil {
+		policy = retry.Backoff{}
+	}
+	deco := &RetryingPaymentsImpl{
+		delegate: ctrl,
+		policy:   policy,
+	}
+	return deco
+}
+
+// Capture retrying base method.
+func (t *RetryingPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	var ret error
+	_ = retry.Do(ctx, t.policy, func() error {
+		ret = t.delegate.Capture(receipt, ctx)
+		return ret
+	})
+	return ret
+}
+
+// Charge retrying base method.
+func (t *RetryingPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund retrying base method.
+func (t *RetryingPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	return t.delegate.Refund(req)
+}
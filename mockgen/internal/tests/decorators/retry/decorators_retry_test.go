@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/retry"
+)
+
+var errFlaky = errors.New("flaky")
+
+// policy retries every call up to 3 times without waiting.
+var policy = retry.Backoff{MaxAttempts: 3, BaseDelay: time.Nanosecond}
+
+// flakyStore is a Store whose calls fail until fails reaches zero.
+type flakyStore struct {
+	decorators.MemStore
+	fails int
+	calls int
+}
+
+func (s *flakyStore) fail() error {
+	s.calls++
+	if s.fails > 0 {
+		s.fails--
+		return errFlaky
+	}
+	return nil
+}
+
+func (s *flakyStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return s.MemStore.Get(ctx, key)
+}
+
+func (s *flakyStore) Put(ctx context.Context, key string, value []byte) error {
+	if err := s.fail(); err != nil {
+		return err
+	}
+	return s.MemStore.Put(ctx, key, value)
+}
+
+func TestRetryingStoreImpl(t *testing.T) {
+	store := &flakyStore{MemStore: decorators.MemStore{"a": []byte("1")}, fails: 2}
+	s := NewRetryingStoreImpl(store, policy)
+
+	v, err := s.Get(context.Background(), "a")
+	if err != nil || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+	if store.calls != 3 {
+		t.Errorf("Get(a) called the delegate %d times, want 3", store.calls)
+	}
+
+	store.fails, store.calls = 5, 0
+	if err := s.Put(context.Background(), "b", nil); err != errFlaky {
+		t.Errorf("Put(b) = %v, want %v", err, errFlaky)
+	}
+	if store.calls != 3 {
+		t.Errorf("Put(b) called the delegate %d times, want 3", store.calls)
+	}
+}
+
+func TestRetryingStoreImpl_ContextDone(t *testing.T) {
+	store := &flakyStore{fails: 5}
+	s := NewRetryingStoreImpl(store, retry.Backoff{MaxAttempts: 5, BaseDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := s.Put(ctx, "a", nil); err != errFlaky {
+		t.Errorf("Put(a) = %v, want %v", err, errFlaky)
+	}
+	if store.calls != 1 {
+		t.Errorf("Put(a) called the delegate %d times, want 1", store.calls)
+	}
+}
+
+// flakyPayments is a Payments whose calls always fail.
+type flakyPayments struct {
+	calls map[string]int
+}
+
+func (p flakyPayments) Charge(context.Context, decorators.Account, int64, time.Duration, string) (string, error) {
+	p.calls["Charge"]++
+	return "", errFlaky
+}
+
+func (p flakyPayments) Capture(string, context.Context) error {
+	p.calls["Capture"]++
+	return errFlaky
+}
+
+func (p flakyPayments) Refund(*decorators.RefundRequest) error {
+	p.calls["Refund"]++
+	return errFlaky
+}
+
+func TestRetryingPaymentsImpl_NonIdempotent(t *testing.T) {
+	payments := flakyPayments{calls: map[string]int{}}
+	p := NewRetryingPaymentsImpl(payments, policy)
+
+	ctx := context.Background()
+	_, _ = p.Charge(ctx, decorators.Account{ID: "a"}, 1, time.Second, "")
+	_ = p.Capture("r", ctx)
+	_ = p.Refund(&decorators.RefundRequest{Ctx: ctx})
+
+	for method, want := range map[string]int{"Charge": 1, "Capture": 3, "Refund": 1} {
+		if got := payments.calls[method]; got != want {
+			t.Errorf("%v called the delegate %d times, want %d", method, got, want)
+		}
+	}
+}
+
+func TestRetryingLedgerImpl(t *testing.T) {
+	l := NewRetryingLedgerImpl(&decorators.MemLedger{}, policy)
+	if err := l.Append(context.Background(), "entry"); err != nil {
+		t.Errorf("Append(entry) = %v, want nil", err)
+	}
+	if err := l.Append(context.Background(), " "); err == nil {
+		t.Error("Append( ) succeeded, want error")
+	}
+}
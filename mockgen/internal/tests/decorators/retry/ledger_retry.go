@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ledger.go -destination=retry/ledger_retry.go -package retry -implementation_type=retry
+//
+
+// Package retry is a generated GoMock package.
+package retry
+
+import (
+	context "context"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	retry "github.com/pableeee/implgen/retry"
+)
+
+// RetryingLedgerImpl is a retry decorator of Ledger interface.
+type RetryingLedgerImpl struct {
+	delegate decorators.Ledger
+	policy   retry.Policy
+}
+
+var _ decorators.Ledger = (*RetryingLedgerImpl)(nil)
+
+// NewRetryingLedgerImpl creates a new retry decorator instance, retrying the
+// failed calls according to policy. Defaults to retry.Backoff{} if policy is nil.
+func NewRetryingLedgerImpl(ctrl decorators.Ledger, policy retry.Policy) *RetryingLedgerImpl {
+	if policy == nil {
+		policy = retry.Backoff{}
+	}
+	deco := &RetryingLedgerImpl{
+		delegate: ctrl,
+		policy:   policy,
+	}
+	return deco
+}
+
+// Append retrying base method.
+func (t *RetryingLedgerImpl) Append(c context.Context, entry string) *decorators.LedgerError {
+	var ret *decorators.LedgerError
+	_ = retry.Do(c, t.policy, func() error {
+		ret = t.delegate.Append(c, entry)
+		if err, ok := any(ret).(error); ok && ret != nil {
+			return err
+		}
+		return nil
+	})
+	return ret
+}
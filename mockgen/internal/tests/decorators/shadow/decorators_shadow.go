@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=shadow/decorators_shadow.go -package shadow -implementation_type=shadow -shadow_methods=Store.Get,Store.Keys
+//
+
+// Package shadow is a generated GoMock package.
+package shadow
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	shadow "github.com/pableeee/implgen/shadow"
+)
+
+// ShadowedStoreImpl is a shadow decorator of Store interface.
+type ShadowedStoreImpl struct {
+	primary  decorators.Store
+	shadowed decorators.Store
+	runner   *shadow.Runner
+}
+
+var _ decorators.Store = (*ShadowedStoreImpl)(nil)
+
+// NewShadowedStoreImpl creates a new shadow decorator instance, serving the calls
+// from primary and repeating them on shadowed with runner. Defaults to
+// shadow.New(shadow.Settings{}) if runner is nil.
+func NewShadowedStoreImpl(primary, shadowed decorators.Store, runner *shadow.Runner) *ShadowedStoreImpl {
+	if runner == nil {
+		runner = shadow.New(shadow.Settings{})
+	}
+	return &ShadowedStoreImpl{primary: primary, shadowed: shadowed, runner: runner}
+}
+
+// Close shadow base method.
+func (t *ShadowedStoreImpl) Close() {
+	t.primary.Close()
+}
+
+// Get shadow base method.
+func (t *ShadowedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	ret, ret_2 := t.primary.Get(ctx, key)
+	t.runner.Go(ctx, "Store.Get", []any{key}, []any{ret, ret_2}, func(ctx context.Context) []any {
+		ret, ret_2 := t.shadowed.Get(ctx, key)
+		return []any{ret, ret_2}
+	})
+	return ret, ret_2
+}
+
+// Keys shadow base method.
+func (t *ShadowedStoreImpl) Keys(prefix string, limit int) []string {
+	ret := t.primary.Keys(prefix, limit)
+	t.runner.Go(context.Background(), "Store.Keys", []any{prefix, limit}, []any{ret}, func(context.Context) []any {
+		ret := t.shadowed.Keys(prefix, limit)
+		return []any{ret}
+	})
+	return ret
+}
+
+// Put shadow base method.
+func (t *ShadowedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	return t.primary.Put(ctx, key, value)
+}
+
+// ShadowedPaymentsImpl is a shadow decorator of Payments interface.
+type ShadowedPaymentsImpl struct {
+	primary  decorators.Payments
+	shadowed decorators.Payments
+	runner   *shadow.Runner
+}
+
+var _ decorators.Payments = (*ShadowedPaymentsImpl)(nil)
+
+// NewShadowedPaymentsImpl creates a new shadow decorator instance, serving the calls
+// from primary and repeating them on shadowed with runner. Defaults to
+// shadow.New(shadow.Settings{}) if runner is nil.
+func NewShadowedPaymentsImpl(primary, shadowed decorators.Payments, runner *shadow.Runner) *ShadowedPaymentsImpl {
+	if runner == nil {
+		runner = shadow.New(shadow.Settings{})
+	}
+	return &ShadowedPaymentsImpl{primary: primary, shadowed: shadowed, runner: runner}
+}
+
+// Capture shadow base method.
+func (t *ShadowedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	return t.primary.Capture(receipt, ctx)
+}
+
+// Charge shadow base method.
+func (t *ShadowedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	return t.primary.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund shadow base method.
+func (t *ShadowedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	return t.primary.Refund(req)
+}
@@ -0,0 +1,57 @@
+package shadow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/shadow"
+)
+
+func TestShadowedStoreImpl(t *testing.T) {
+	primary := decorators.MemStore{"a": []byte("1"), "b": []byte("2")}
+	shadowed := decorators.MemStore{"a": []byte("1"), "b": []byte("3")}
+	var mismatches []shadow.Mismatch
+	runner := shadow.New(shadow.Settings{OnMismatch: func(m shadow.Mismatch) {
+		mismatches = append(mismatches, m)
+	}})
+	s := NewShadowedStoreImpl(primary, shadowed, runner)
+
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		if v, err := s.Get(ctx, key); string(v) != string(primary[key]) {
+			t.Errorf("Get(%v) = %q, %v, want the results of the primary", key, v, err)
+		}
+		runner.Wait()
+	}
+	_ = s.Keys("", 10)
+	runner.Wait()
+
+	if len(mismatches) != 1 {
+		t.Fatalf("mismatches = %+v, want the one of Get(b)", mismatches)
+	}
+	m := mismatches[0]
+	if m.Method != "Store.Get" || len(m.Args) != 1 || m.Args[0] != "b" {
+		t.Errorf("mismatch = %+v, want Store.Get(b)", m)
+	}
+	if string(m.Primary[0].([]byte)) != "2" || string(m.Shadow[0].([]byte)) != "3" {
+		t.Errorf("mismatch = %+v, want the results 2 and 3", m)
+	}
+
+	// The calls of the methods that are not selected are not repeated.
+	if err := s.Put(ctx, "d", []byte("4")); err != nil {
+		t.Fatal(err)
+	}
+	runner.Wait()
+	if _, ok := shadowed["d"]; ok {
+		t.Error("Put() was repeated on the shadow")
+	}
+}
+
+func TestShadowedStoreImpl_NilRunner(t *testing.T) {
+	primary := decorators.MemStore{"a": []byte("1")}
+	s := NewShadowedStoreImpl(primary, decorators.MemStore{}, nil)
+	if v, err := s.Get(context.Background(), "a"); string(v) != "1" || err != nil {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+}
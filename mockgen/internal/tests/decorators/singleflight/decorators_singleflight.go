@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=singleflight/decorators_singleflight.go -package singleflight -implementation_type=singleflight -singleflight_methods=Store,Payments
+//
+
+// Package singleflight is a generated GoMock package.
+package singleflight
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	singleflight "github.com/pableeee/implgen/singleflight"
+)
+
+// CoalescedStoreImpl is a singleflight decorator of Store interface.
+type CoalescedStoreImpl struct {
+	delegate decorators.Store
+	group    singleflight.Group
+}
+
+var _ decorators.Store = (*CoalescedStoreImpl)(nil)
+
+// coalescedStoreImplGetKey keys the calls of Get.
+type coalescedStoreImplGetKey struct {
+	key string
+}
+
+var _ map[coalescedStoreImplGetKey]struct{}
+
+// coalescedStoreImplGetResults holds the results of a call of Get.
+type coalescedStoreImplGetResults struct {
+	r0 []byte
+	r1 error
+}
+
+// coalescedStoreImplKeysKey keys the calls of Keys.
+type coalescedStoreImplKeysKey struct {
+	prefix string
+	limit  int
+}
+
+var _ map[coalescedStoreImplKeysKey]struct{}
+
+// coalescedStoreImplKeysResults holds the results of a call of Keys.
+type coalescedStoreImplKeysResults struct {
+	r0 []string
+}
+
+// NewCoalescedStoreImpl creates a new singleflight decorator instance.
+func NewCoalescedStoreImpl(ctrl decorators.Store) *CoalescedStoreImpl {
+	return &CoalescedStoreImpl{delegate: ctrl}
+}
+
+// Close singleflight base method.
+func (t *CoalescedStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get singleflight base method.
+func (t *CoalescedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	v, err := t.group.Do(ctx, coalescedStoreImplGetKey{key: key}, func(ctx context.Context) any {
+		ret, ret_2 := t.delegate.Get(ctx, key)
+		return coalescedStoreImplGetResults{r0: ret, r1: ret_2}
+	})
+	if err != nil {
+		var ret []byte
+		return ret, err
+	}
+	r := v.(coalescedStoreImplGetResults)
+	return r.r0, r.r1
+}
+
+// Keys singleflight base method.
+func (t *CoalescedStoreImpl) Keys(prefix string, limit int) []string {
+	v, _ := t.group.Do(context.Background(), coalescedStoreImplKeysKey{prefix: prefix, limit: limit}, func(context.Context) any {
+		ret := t.delegate.Keys(prefix, limit)
+		return coalescedStoreImplKeysResults{r0: ret}
+	})
+	r := v.(coalescedStoreImplKeysResults)
+	return r.r0
+}
+
+// Put singleflight base method.
+func (t *CoalescedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	return t.delegate.Put(ctx, key, value)
+}
+
+// CoalescedPaymentsImpl is a singleflight decorator of Payments interface.
+type CoalescedPaymentsImpl struct {
+	delegate decorators.Payments
+	group    singleflight.Group
+}
+
+var _ decorators.Payments = (*CoalescedPaymentsImpl)(nil)
+
+// coalescedPaymentsImplCaptureKey keys the calls of Capture.
+type coalescedPaymentsImplCaptureKey struct {
+	receipt string
+}
+
+var _ map[coalescedPaymentsImplCaptureKey]struct{}
+
+// coalescedPaymentsImplCaptureResults holds the results of a call of Capture.
+type coalescedPaymentsImplCaptureResults struct {
+	r0 error
+}
+
+// coalescedPaymentsImplRefundKey keys the calls of Refund.
+type coalescedPaymentsImplRefundKey struct {
+	req *decorators.RefundRequest
+}
+
+var _ map[coalescedPaymentsImplRefundKey]struct{}
+
+// coalescedPaymentsImplRefundResults holds the results of a call of Refund.
+type coalescedPaymentsImplRefundResults struct {
+	r0 error
+}
+
+// NewCoalescedPaymentsImpl creates a new singleflight decorator instance.
+func NewCoalescedPaymentsImpl(ctrl decorators.Payments) *CoalescedPaymentsImpl {
+	return &CoalescedPaymentsImpl{delegate: ctrl}
+}
+
+// Capture singleflight base method.
+func (t *CoalescedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	v, err := t.group.Do(ctx, coalescedPaymentsImplCaptureKey{receipt: receipt}, func(ctx context.Context) any {
+		ret := t.delegate.Capture(receipt, ctx)
+		return coalescedPaymentsImplCaptureResults{r0: ret}
+	})
+	if err != nil {
+		return err
+	}
+	r := v.(coalescedPaymentsImplCaptureResults)
+	return r.r0
+}
+
+// Charge singleflight base method.
+func (t *CoalescedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund singleflight base method.
+func (t *CoalescedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	v, err := t.group.Do(context.Background(), coalescedPaymentsImplRefundKey{req: req}, func(context.Context) any {
+		ret := t.delegate.Refund(req)
+		return coalescedPaymentsImplRefundResults{r0: ret}
+	})
+	if err != nil {
+		return err
+	}
+	r := v.(coalescedPaymentsImplRefundResults)
+	return r.r0
+}
@@ -0,0 +1,100 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// blockingStore is a Store whose Get blocks until release is closed.
+type blockingStore struct {
+	decorators.MemStore
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+	}
+	<-s.release
+	return s.MemStore.Get(ctx, key)
+}
+
+func newBlockingStore() *blockingStore {
+	return &blockingStore{
+		MemStore: decorators.MemStore{"a": []byte("1")},
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+}
+
+func TestCoalescedStoreImpl(t *testing.T) {
+	store := newBlockingStore()
+	s := NewCoalescedStoreImpl(store)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([][]byte, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = s.Get(context.Background(), "a")
+		}(i)
+	}
+	<-store.started
+	close(store.release)
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil || string(results[i]) != "1" {
+			t.Errorf("Get(a) = %q, %v, want 1, nil", results[i], errs[i])
+		}
+	}
+	// The callers that joined the call in flight did not call the delegate.
+	if n := store.calls.Load(); n < 1 || n > callers {
+		t.Errorf("delegate calls = %d, want between 1 and %d", n, callers)
+	}
+
+	// Calls with other arguments are not coalesced.
+	if _, err := s.Get(context.Background(), "b"); err != decorators.ErrNotFound {
+		t.Errorf("Get(b) = %v, want %v", err, decorators.ErrNotFound)
+	}
+	if n := store.calls.Load(); n < 2 {
+		t.Errorf("delegate calls = %d after a new call, want at least 2", n)
+	}
+}
+
+func TestCoalescedStoreImpl_Cancel(t *testing.T) {
+	store := newBlockingStore()
+	s := NewCoalescedStoreImpl(store)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Get(context.Background(), "a")
+		done <- err
+	}()
+	<-store.started
+
+	// A waiter joining the call in flight with a canceled context stops
+	// waiting, the others do not.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get(a) with a canceled context = %v, want %v", err, context.Canceled)
+	}
+	close(store.release)
+	if err := <-done; err != nil {
+		t.Errorf("Get(a) = %v, want nil", err)
+	}
+	if n := store.calls.Load(); n != 1 {
+		t.Errorf("delegate calls = %d, want 1", n)
+	}
+}
@@ -0,0 +1,20 @@
+package decorators
+
+import "context"
+
+//go:generate mockgen -source=sinks.go -destination=noop/sinks_noop.go -package noop -implementation_type=noop
+//go:generate mockgen -source=sinks.go -destination=unimplemented/sinks_unimplemented.go -package unimplemented -implementation_type=unimplemented -unimplemented_guard
+
+// AuditSink records audit events.
+type AuditSink interface {
+	Record(ctx context.Context, event string) error
+	Events(ctx context.Context) (<-chan string, error)
+	Flush()
+}
+
+// Queue is a queue of values.
+type Queue[T any] interface {
+	Push(v T) error
+	Pop() (T, bool)
+	Drain() <-chan T
+}
@@ -0,0 +1,276 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=stub/decorators_stub.go -package stub -implementation_type=stub -stub_record
+//
+
+// Package stub is a generated GoMock package.
+package stub
+
+import (
+	context "context"
+	sync "sync"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+// StubStore is a stub of Store interface, whose methods call the functions
+// of its fields.
+type StubStore struct {
+	CloseFunc func()
+	GetFunc   func(ctx context.Context, key string) ([]byte, error)
+	KeysFunc  func(prefix string, limit int) []string
+	PutFunc   func(ctx context.Context, key string, value []byte) error
+
+	mu         sync.Mutex
+	callsClose []StubStoreCloseCall
+	callsGet   []StubStoreGetCall
+	callsKeys  []StubStoreKeysCall
+	callsPut   []StubStorePutCall
+}
+
+var _ decorators.Store = (*StubStore)(nil)
+
+// StubStoreCloseCall holds the arguments of a call of Close on a StubStore.
+type StubStoreCloseCall struct {
+}
+
+// CloseCalls returns the arguments of the calls of Close, in order.
+func (t *StubStore) CloseCalls() []StubStoreCloseCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubStoreCloseCall(nil), t.callsClose...)
+}
+
+// CloseCallCount returns the number of calls of Close.
+func (t *StubStore) CloseCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsClose)
+}
+
+// StubStoreGetCall holds the arguments of a call of Get on a StubStore.
+type StubStoreGetCall struct {
+	Ctx context.Context
+	Key string
+}
+
+// GetCalls returns the arguments of the calls of Get, in order.
+func (t *StubStore) GetCalls() []StubStoreGetCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubStoreGetCall(nil), t.callsGet...)
+}
+
+// GetCallCount returns the number of calls of Get.
+func (t *StubStore) GetCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsGet)
+}
+
+// StubStoreKeysCall holds the arguments of a call of Keys on a StubStore.
+type StubStoreKeysCall struct {
+	Prefix string
+	Limit  int
+}
+
+// KeysCalls returns the arguments of the calls of Keys, in order.
+func (t *StubStore) KeysCalls() []StubStoreKeysCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubStoreKeysCall(nil), t.callsKeys...)
+}
+
+// KeysCallCount returns the number of calls of Keys.
+func (t *StubStore) KeysCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsKeys)
+}
+
+// StubStorePutCall holds the arguments of a call of Put on a StubStore.
+type StubStorePutCall struct {
+	Ctx   context.Context
+	Key   string
+	Value []byte
+}
+
+// PutCalls returns the arguments of the calls of Put, in order.
+func (t *StubStore) PutCalls() []StubStorePutCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubStorePutCall(nil), t.callsPut...)
+}
+
+// PutCallCount returns the number of calls of Put.
+func (t *StubStore) PutCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsPut)
+}
+
+// Close stub base method.
+func (t *StubStore) Close() {
+	t.mu.Lock()
+	t.callsClose = append(t.callsClose, StubStoreCloseCall{})
+	t.mu.Unlock()
+	if t.CloseFunc == nil {
+		return
+	}
+	t.CloseFunc()
+}
+
+// Get stub base method.
+func (t *StubStore) Get(ctx context.Context, key string) ([]byte, error) {
+	t.mu.Lock()
+	t.callsGet = append(t.callsGet, StubStoreGetCall{Ctx: ctx, Key: key})
+	t.mu.Unlock()
+	if t.GetFunc == nil {
+		var ret []byte
+		var ret_2 error
+		return ret, ret_2
+	}
+	return t.GetFunc(ctx, key)
+}
+
+// Keys stub base method.
+func (t *StubStore) Keys(prefix string, limit int) []string {
+	t.mu.Lock()
+	t.callsKeys = append(t.callsKeys, StubStoreKeysCall{Prefix: prefix, Limit: limit})
+	t.mu.Unlock()
+	if t.KeysFunc == nil {
+		var ret []string
+		return ret
+	}
+	return t.KeysFunc(prefix, limit)
+}
+
+// Put stub base method.
+func (t *StubStore) Put(ctx context.Context, key string, value []byte) error {
+	t.mu.Lock()
+	t.callsPut = append(t.callsPut, StubStorePutCall{Ctx: ctx, Key: key, Value: value})
+	t.mu.Unlock()
+	if t.PutFunc == nil {
+		var ret error
+		return ret
+	}
+	return t.PutFunc(ctx, key, value)
+}
+
+// StubPayments is a stub of Payments interface, whose methods call the functions
+// of its fields.
+type StubPayments struct {
+	CaptureFunc func(receipt string, ctx context.Context) error
+	ChargeFunc  func(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error)
+	RefundFunc  func(req *decorators.RefundRequest) error
+
+	mu           sync.Mutex
+	callsCapture []StubPaymentsCaptureCall
+	callsCharge  []StubPaymentsChargeCall
+	callsRefund  []StubPaymentsRefundCall
+}
+
+var _ decorators.Payments = (*StubPayments)(nil)
+
+// StubPaymentsCaptureCall holds the arguments of a call of Capture on a StubPayments.
+type StubPaymentsCaptureCall struct {
+	Receipt string
+	Ctx     context.Context
+}
+
+// CaptureCalls returns the arguments of the calls of Capture, in order.
+func (t *StubPayments) CaptureCalls() []StubPaymentsCaptureCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubPaymentsCaptureCall(nil), t.callsCapture...)
+}
+
+// CaptureCallCount returns the number of calls of Capture.
+func (t *StubPayments) CaptureCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsCapture)
+}
+
+// StubPaymentsChargeCall holds the arguments of a call of Charge on a StubPayments.
+type StubPaymentsChargeCall struct {
+	Ctx     context.Context
+	Account decorators.Account
+	Amount  int64
+	Timeout time.Duration
+	Token   string
+}
+
+// ChargeCalls returns the arguments of the calls of Charge, in order.
+func (t *StubPayments) ChargeCalls() []StubPaymentsChargeCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubPaymentsChargeCall(nil), t.callsCharge...)
+}
+
+// ChargeCallCount returns the number of calls of Charge.
+func (t *StubPayments) ChargeCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsCharge)
+}
+
+// StubPaymentsRefundCall holds the arguments of a call of Refund on a StubPayments.
+type StubPaymentsRefundCall struct {
+	Req *decorators.RefundRequest
+}
+
+// RefundCalls returns the arguments of the calls of Refund, in order.
+func (t *StubPayments) RefundCalls() []StubPaymentsRefundCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]StubPaymentsRefundCall(nil), t.callsRefund...)
+}
+
+// RefundCallCount returns the number of calls of Refund.
+func (t *StubPayments) RefundCallCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.callsRefund)
+}
+
+// Capture stub base method.
+func (t *StubPayments) Capture(receipt string, ctx context.Context) error {
+	t.mu.Lock()
+	t.callsCapture = append(t.callsCapture, StubPaymentsCaptureCall{Receipt: receipt, Ctx: ctx})
+	t.mu.Unlock()
+	if t.CaptureFunc == nil {
+		var ret error
+		return ret
+	}
+	return t.CaptureFunc(receipt, ctx)
+}
+
+// Charge stub base method.
+func (t *StubPayments) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	t.mu.Lock()
+	t.callsCharge = append(t.callsCharge, StubPaymentsChargeCall{Ctx: ctx, Account: account, Amount: amount, Timeout: timeout, Token: token})
+	t.mu.Unlock()
+	if t.ChargeFunc == nil {
+		var ret string
+		var ret_2 error
+		return ret, ret_2
+	}
+	return t.ChargeFunc(ctx, account, amount, timeout, token)
+}
+
+// Refund stub base method.
+func (t *StubPayments) Refund(req *decorators.RefundRequest) error {
+	t.mu.Lock()
+	t.callsRefund = append(t.callsRefund, StubPaymentsRefundCall{Req: req})
+	t.mu.Unlock()
+	if t.RefundFunc == nil {
+		var ret error
+		return ret
+	}
+	return t.RefundFunc(req)
+}
@@ -0,0 +1,50 @@
+package stub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestStubStore(t *testing.T) {
+	s := &StubStore{
+		GetFunc: func(_ context.Context, key string) ([]byte, error) {
+			if key == "a" {
+				return []byte("1"), nil
+			}
+			return nil, decorators.ErrNotFound
+		},
+	}
+
+	ctx := context.Background()
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, nil", v, err)
+	}
+	if _, err := s.Get(ctx, "b"); !errors.Is(err, decorators.ErrNotFound) {
+		t.Errorf("Get(b) = %v, want %v", err, decorators.ErrNotFound)
+	}
+	// The methods whose function is not set return zero values.
+	if err := s.Put(ctx, "a", []byte("2")); err != nil {
+		t.Errorf("Put() = %v, want nil", err)
+	}
+	if keys := s.Keys("", 10); keys != nil {
+		t.Errorf("Keys() = %q, want nil", keys)
+	}
+	s.Close()
+
+	if n := s.GetCallCount(); n != 2 {
+		t.Errorf("GetCallCount() = %d, want 2", n)
+	}
+	calls := s.GetCalls()
+	if len(calls) != 2 || calls[0].Key != "a" || calls[1].Key != "b" {
+		t.Errorf("GetCalls() = %+v, want the calls with a and b", calls)
+	}
+	if calls := s.PutCalls(); len(calls) != 1 || string(calls[0].Value) != "2" {
+		t.Errorf("PutCalls() = %+v, want the call with a and 2", calls)
+	}
+	if n := s.CloseCallCount(); n != 1 {
+		t.Errorf("CloseCallCount() = %d, want 1", n)
+	}
+}
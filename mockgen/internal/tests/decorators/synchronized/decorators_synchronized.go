@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=synchronized/decorators_synchronized.go -package synchronized -implementation_type=synchronized -synchronized_readonly=Store.Get,Store.Keys -synchronized_context
+//
+
+// Package synchronized is a generated GoMock package.
+package synchronized
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	synchronized "github.com/pableeee/implgen/synchronized"
+)
+
+// SynchronizedStore is a synchronized decorator of Store interface.
+type SynchronizedStore struct {
+	delegate decorators.Store
+	mu       synchronized.RWMutex
+}
+
+var _ decorators.Store = (*SynchronizedStore)(nil)
+
+// NewSynchronizedStore creates a new synchronized decorator instance, serializing the
+// calls of ctrl.
+func NewSynchronizedStore(ctrl decorators.Store) *SynchronizedStore {
+	return &SynchronizedStore{delegate: ctrl}
+}
+
+// Close synchronized base method.
+func (t *SynchronizedStore) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.delegate.Close()
+}
+
+// Get synchronized base method.
+func (t *SynchronizedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := t.mu.RLockContext(ctx); err != nil {
+		var ret []byte
+		return ret, err
+	}
+	defer t.mu.RUnlock()
+	return t.delegate.Get(ctx, key)
+}
+
+// Keys synchronized base method.
+func (t *SynchronizedStore) Keys(prefix string, limit int) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put synchronized base method.
+func (t *SynchronizedStore) Put(ctx context.Context, key string, value []byte) error {
+	if err := t.mu.LockContext(ctx); err != nil {
+		return err
+	}
+	defer t.mu.Unlock()
+	return t.delegate.Put(ctx, key, value)
+}
+
+// SynchronizedPayments is a synchronized decorator of Payments interface.
+type SynchronizedPayments struct {
+	delegate decorators.Payments
+	mu       synchronized.RWMutex
+}
+
+var _ decorators.Payments = (*SynchronizedPayments)(nil)
+
+// NewSynchronizedPayments creates a new synchronized decorator instance, serializing the
+// calls of ctrl.
+func NewSynchronizedPayments(ctrl decorators.Payments) *SynchronizedPayments {
+	return &SynchronizedPayments{delegate: ctrl}
+}
+
+// Capture synchronized base method.
+func (t *SynchronizedPayments) Capture(receipt string, ctx context.Context) error {
+	if err := t.mu.LockContext(ctx); err != nil {
+		return err
+	}
+	defer t.mu.Unlock()
+	return t.delegate.Capture(receipt, ctx)
+}
+
+// Charge synchronized base method.
+func (t *SynchronizedPayments) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	if err := t.mu.LockContext(ctx); err != nil {
+		var ret string
+		return ret, err
+	}
+	defer t.mu.Unlock()
+	return t.delegate.Charge(ctx, account, amount, timeout, token)
+}
+
+// Refund synchronized base method.
+func (t *SynchronizedPayments) Refund(req *decorators.RefundRequest) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.delegate.Refund(req)
+}
@@ -0,0 +1,75 @@
+package synchronized
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestSynchronizedStore(t *testing.T) {
+	// MemStore is not safe for concurrent use, which the race detector
+	// reports unless the calls are serialized.
+	s := NewSynchronizedStore(decorators.MemStore{})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprint(i)
+			if err := s.Put(ctx, key, []byte(key)); err != nil {
+				t.Error(err)
+			}
+			if v, err := s.Get(ctx, key); err != nil || string(v) != key {
+				t.Errorf("Get(%v) = %q, %v", key, v, err)
+			}
+			_ = s.Keys("", 100)
+		}(i)
+	}
+	wg.Wait()
+	if keys := s.Keys("", 100); len(keys) != 20 {
+		t.Errorf("Keys() = %v, want 20 keys", keys)
+	}
+}
+
+// blockingStore is a MemStore whose Close blocks until closing is closed.
+type blockingStore struct {
+	decorators.MemStore
+	closing chan struct{}
+}
+
+func (s blockingStore) Close() { <-s.closing }
+
+func TestSynchronizedStore_Context(t *testing.T) {
+	bs := blockingStore{MemStore: decorators.MemStore{"a": []byte("1")}, closing: make(chan struct{})}
+	s := NewSynchronizedStore(bs)
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	// Wait for Close to hold the lock.
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		_, err := s.Get(ctx, "a")
+		cancel()
+		if err == context.DeadlineExceeded {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Get() = %v", err)
+		}
+	}
+
+	close(bs.closing)
+	<-closed
+	if v, err := s.Get(context.Background(), "a"); err != nil || string(v) != "1" {
+		t.Errorf("Get() = %q, %v, want 1, nil", v, err)
+	}
+}
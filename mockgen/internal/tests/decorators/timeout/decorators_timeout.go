@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=timeout/decorators_timeout.go -package timeout -implementation_type=timeout
+//
+
+// Package timeout is a generated GoMock package.
+package timeout
+
+import (
+	context "context"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	timeout "github.com/pableeee/implgen/timeout"
+)
+
+// TimeoutStoreImpl is a timeout decorator of Store interface.
+type TimeoutStoreImpl struct {
+	delegate decorators.Store
+	timeouts map[string]time.Duration
+}
+
+var _ decorators.Store = (*TimeoutStoreImpl)(nil)
+
+// TimeoutStoreImplOption configures a TimeoutStoreImpl.
+type TimeoutStoreImplOption func(*timeoutStoreImplOptions)
+
+type timeoutStoreImplOptions struct {
+	timeout        time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+// WithTimeoutStoreImplTimeout sets the timeout of the calls of the methods of a
+// TimeoutStoreImpl. Defaults to no timeout.
+func WithTimeoutStoreImplTimeout(d time.Duration) TimeoutStoreImplOption {
+	return func(o *timeoutStoreImplOptions) {
+		o.timeout = d
+	}
+}
+
+// WithTimeoutStoreImplMethodTimeouts sets the timeouts of the calls of the methods
+// of a TimeoutStoreImpl, by method name, in place of the one set by
+// WithTimeoutStoreImplTimeout. A zero timeout sets none.
+func WithTimeoutStoreImplMethodTimeouts(timeouts map[string]time.Duration) TimeoutStoreImplOption {
+	return func(o *timeoutStoreImplOptions) {
+		o.methodTimeouts = timeouts
+	}
+}
+
+// NewTimeoutStoreImpl creates a new timeout decorator instance.
+func NewTimeoutStoreImpl(ctrl decorators.Store, opts ...TimeoutStoreImplOption) *TimeoutStoreImpl {
+	o := timeoutStoreImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TimeoutStoreImpl{
+		delegate: ctrl,
+		timeouts: timeout.NewMethods(o.timeout, o.methodTimeouts, "Store", "Get", "Put"),
+	}
+	return deco
+}
+
+// Close timeout base method.
+func (t *TimeoutStoreImpl) Close() {
+	t.delegate.Close()
+}
+
+// Get timeout base method.
+func (t *TimeoutStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	d, ok := t.timeouts["Get"]
+	if !ok {
+		return t.delegate.Get(ctx, key)
+	}
+	ctx, cancel := timeout.WithTimeout(ctx, "Store.Get", d)
+	defer cancel()
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	return ret, timeout.Wrap(ctx, ret_2)
+}
+
+// Keys timeout base method.
+func (t *TimeoutStoreImpl) Keys(prefix string, limit int) []string {
+	return t.delegate.Keys(prefix, limit)
+}
+
+// Put timeout base method.
+func (t *TimeoutStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	d, ok := t.timeouts["Put"]
+	if !ok {
+		return t.delegate.Put(ctx, key, value)
+	}
+	ctx, cancel := timeout.WithTimeout(ctx, "Store.Put", d)
+	defer cancel()
+	ret := t.delegate.Put(ctx, key, value)
+	return timeout.Wrap(ctx, ret)
+}
+
+// TimeoutPaymentsImpl is a timeout decorator of Payments interface.
+type TimeoutPaymentsImpl struct {
+	delegate decorators.Payments
+	timeouts map[string]time.Duration
+}
+
+var _ decorators.Payments = (*TimeoutPaymentsImpl)(nil)
+
+// TimeoutPaymentsImplOption configures a TimeoutPaymentsImpl.
+type TimeoutPaymentsImplOption func(*timeoutPaymentsImplOptions)
+
+type timeoutPaymentsImplOptions struct {
+	timeout        time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+// WithTimeoutPaymentsImplTimeout sets the timeout of the calls of the methods of a
+// TimeoutPaymentsImpl. Defaults to no timeout.
+func WithTimeoutPaymentsImplTimeout(d time.Duration) TimeoutPaymentsImplOption {
+	return func(o *timeoutPaymentsImplOptions) {
+		o.timeout = d
+	}
+}
+
+// WithTimeoutPaymentsImplMethodTimeouts sets the timeouts of the calls of the methods
+// of a TimeoutPaymentsImpl, by method name, in place of the one set by
+// WithTimeoutPaymentsImplTimeout. A zero timeout sets none.
+func WithTimeoutPaymentsImplMethodTimeouts(timeouts map[string]time.Duration) TimeoutPaymentsImplOption {
+	return func(o *timeoutPaymentsImplOptions) {
+		o.methodTimeouts = timeouts
+	}
+}
+
+// NewTimeoutPaymentsImpl creates a new timeout decorator instance.
+func NewTimeoutPaymentsImpl(ctrl decorators.Payments, opts ...TimeoutPaymentsImplOption) *TimeoutPaymentsImpl {
+	o := timeoutPaymentsImplOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TimeoutPaymentsImpl{
+		delegate: ctrl,
+		timeouts: timeout.NewMethods(o.timeout, o.methodTimeouts, "Payments", "Capture", "Charge"),
+	}
+	return deco
+}
+
+// Capture timeout base method.
+func (t *TimeoutPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	d, ok := t.timeouts["Capture"]
+	if !ok {
+		return t.delegate.Capture(receipt, ctx)
+	}
+	ctx, cancel := timeout.WithTimeout(ctx, "Payments.Capture", d)
+	defer cancel()
+	ret := t.delegate.Capture(receipt, ctx)
+	return timeout.Wrap(ctx, ret)
+}
+
+// Charge timeout base method.
+func (t *TimeoutPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout_ time.Duration, token string) (string, error) {
+	d, ok := t.timeouts["Charge"]
+	if !ok {
+		return t.delegate.Charge(ctx, account, amount, timeout_, token)
+	}
+	ctx, cancel := timeout.WithTimeout(ctx, "Payments.Charge", d)
+	defer cancel()
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout_, token)
+	return ret, timeout.Wrap(ctx, ret_2)
+}
+
+// Refund timeout base method.
+func (t *TimeoutPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	return t.delegate.Refund(req)
+}
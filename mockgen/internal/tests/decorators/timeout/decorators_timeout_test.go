@@ -0,0 +1,75 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	"github.com/pableeee/implgen/timeout"
+)
+
+// slowStore is a Store whose Get calls last until their context is done.
+type slowStore struct {
+	decorators.MemStore
+}
+
+func (slowStore) Get(ctx context.Context, _ string) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTimeoutStoreImpl(t *testing.T) {
+	s := NewTimeoutStoreImpl(slowStore{decorators.MemStore{}},
+		WithTimeoutStoreImplTimeout(time.Millisecond))
+
+	_, err := s.Get(context.Background(), "a")
+	var timeoutErr *timeout.Error
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get(a) = %v, want a *timeout.Error matching %v", err, context.DeadlineExceeded)
+	}
+	if timeoutErr.Method != "Store.Get" || timeoutErr.Timeout != time.Millisecond {
+		t.Errorf("Get(a) = %+v, want the method and timeout of the call", timeoutErr)
+	}
+
+	if err := s.Put(context.Background(), "a", nil); err != nil {
+		t.Errorf("Put(a) = %v", err)
+	}
+}
+
+func TestTimeoutStoreImpl_MethodTimeouts(t *testing.T) {
+	s := NewTimeoutStoreImpl(slowStore{decorators.MemStore{}},
+		WithTimeoutStoreImplTimeout(time.Millisecond),
+		WithTimeoutStoreImplMethodTimeouts(map[string]time.Duration{"Get": 0}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	// Without a timeout of its own, Get fails with the error of its context.
+	if _, err := s.Get(ctx, "a"); err != context.DeadlineExceeded {
+		t.Errorf("Get(a) = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// deadlinePayments is a Payments whose Charge calls report whether their
+// context has a deadline.
+type deadlinePayments struct {
+	decorators.FakePayments
+}
+
+func (deadlinePayments) Charge(ctx context.Context, _ decorators.Account, _ int64, _ time.Duration, _ string) (string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return "", errors.New("no deadline")
+	}
+	return "receipt", nil
+}
+
+func TestTimeoutPaymentsImpl(t *testing.T) {
+	p := NewTimeoutPaymentsImpl(deadlinePayments{},
+		WithTimeoutPaymentsImplMethodTimeouts(map[string]time.Duration{"Charge": time.Second}))
+
+	// The timeout argument of Charge does not shadow the timeout package.
+	if _, err := p.Charge(context.Background(), decorators.Account{}, 1, time.Minute, ""); err != nil {
+		t.Errorf("Charge() = %v", err)
+	}
+}
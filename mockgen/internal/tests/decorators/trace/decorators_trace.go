@@ -0,0 +1,253 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: decorators.go
+//
+// Generated by this command:
+//
+//	mockgen -source=decorators.go -destination=trace/decorators_trace.go -package trace -implementation_type=trace -attribute_args=Payments -attribute_results=Payments.Charge -attribute_exclude=token -trace_context_free=link
+//
+
+// Package trace is a generated GoMock package.
+package trace
+
+import (
+	context "context"
+	fmt "fmt"
+	time "time"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	otel "go.opentelemetry.io/otel"
+	attribute "go.opentelemetry.io/otel/attribute"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+)
+
+// TracedStoreImpl is a tracing decorator of Store interface.
+type TracedStoreImpl struct {
+	delegate  decorators.Store
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+var _ decorators.Store = (*TracedStoreImpl)(nil)
+
+// TracedStoreImplOption configures a TracedStoreImpl.
+type TracedStoreImplOption func(*tracedStoreImplOptions)
+
+type tracedStoreImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedStoreImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedStoreImpl. Defaults to the global TracerProvider.
+func WithTracedStoreImplTracerProvider(tp trace.TracerProvider) TracedStoreImplOption {
+	return func(o *tracedStoreImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedStoreImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedStoreImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedStoreImplErrorClassifier(isFailure func(err error) bool) TracedStoreImplOption {
+	return func(o *tracedStoreImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedStoreImpl creates a new trace decorator instance.
+func NewTracedStoreImpl(ctrl decorators.Store, opts ...TracedStoreImplOption) *TracedStoreImpl {
+	o := tracedStoreImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedStoreImpl{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/decorators"), isFailure: o.isFailure}
+	return deco
+}
+
+// Close traced base method.
+func (t *TracedStoreImpl) Close() {
+	_, span := t.tracer.Start(context.Background(), "Store.Close")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Close()
+}
+
+// Get traced base method.
+func (t *TracedStoreImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, span := t.tracer.Start(ctx, "Store.Get")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Get(ctx, key)
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Keys traced base method.
+func (t *TracedStoreImpl) Keys(prefix string, limit int) []string {
+	_, span := t.tracer.Start(context.Background(), "Store.Keys")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Keys(prefix, limit)
+	return ret
+}
+
+// Put traced base method.
+func (t *TracedStoreImpl) Put(ctx context.Context, key string, value []byte) error {
+	ctx, span := t.tracer.Start(ctx, "Store.Put")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Put(ctx, key, value)
+	if ret != nil && t.isFailure(ret) {
+		span.RecordError(ret)
+		span.SetStatus(codes.Error, ret.Error())
+	}
+	return ret
+}
+
+// TracedPaymentsImpl is a tracing decorator of Payments interface.
+type TracedPaymentsImpl struct {
+	delegate  decorators.Payments
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+var _ decorators.Payments = (*TracedPaymentsImpl)(nil)
+
+// TracedPaymentsImplOption configures a TracedPaymentsImpl.
+type TracedPaymentsImplOption func(*tracedPaymentsImplOptions)
+
+type tracedPaymentsImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedPaymentsImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedPaymentsImpl. Defaults to the global TracerProvider.
+func WithTracedPaymentsImplTracerProvider(tp trace.TracerProvider) TracedPaymentsImplOption {
+	return func(o *tracedPaymentsImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedPaymentsImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedPaymentsImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedPaymentsImplErrorClassifier(isFailure func(err error) bool) TracedPaymentsImplOption {
+	return func(o *tracedPaymentsImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedPaymentsImpl creates a new trace decorator instance.
+func NewTracedPaymentsImpl(ctrl decorators.Payments, opts ...TracedPaymentsImplOption) *TracedPaymentsImpl {
+	o := tracedPaymentsImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedPaymentsImpl{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/decorators"), isFailure: o.isFailure}
+	return deco
+}
+
+// Capture traced base method.
+func (t *TracedPaymentsImpl) Capture(receipt string, ctx context.Context) error {
+	ctx, span := t.tracer.Start(ctx, "Payments.Capture")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	span.SetAttributes(attribute.String("receipt", receipt))
+	ret := t.delegate.Capture(receipt, ctx)
+	if ret != nil && t.isFailure(ret) {
+		span.RecordError(ret)
+		span.SetStatus(codes.Error, ret.Error())
+	}
+	return ret
+}
+
+// Charge traced base method.
+func (t *TracedPaymentsImpl) Charge(ctx context.Context, account decorators.Account, amount int64, timeout time.Duration, token string) (string, error) {
+	ctx, span := t.tracer.Start(ctx, "Payments.Charge")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	span.SetAttributes(attribute.Int64("amount", int64(amount)), attribute.String("timeout", timeout.String()))
+	if a, ok := any(account).(interface {
+		SpanAttributes(string) []attribute.KeyValue
+	}); ok {
+		span.SetAttributes(a.SpanAttributes("account")...)
+	}
+	ret, ret_2 := t.delegate.Charge(ctx, account, amount, timeout, token)
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	span.SetAttributes(attribute.String("receipt", ret))
+	return ret, ret_2
+}
+
+// Refund traced base method.
+func (t *TracedPaymentsImpl) Refund(req *decorators.RefundRequest) error {
+	var links []trace.Link
+	if c, ok := any(req).(interface{ Context() context.Context }); ok && req != nil {
+		if sc := trace.SpanContextFromContext(c.Context()); sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	_, span := t.tracer.Start(context.Background(), "Payments.Refund", trace.WithLinks(links...))
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	if a, ok := any(req).(interface {
+		SpanAttributes(string) []attribute.KeyValue
+	}); ok && req != nil {
+		span.SetAttributes(a.SpanAttributes("req")...)
+	}
+	ret := t.delegate.Refund(req)
+	if ret != nil && t.isFailure(ret) {
+		span.RecordError(ret)
+		span.SetStatus(codes.Error, ret.Error())
+	}
+	return ret
+}
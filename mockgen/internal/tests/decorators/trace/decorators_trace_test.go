@@ -0,0 +1,159 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestTracedPaymentsImpl(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	p := NewTracedPaymentsImpl(decorators.FakePayments{})
+
+	ctx := context.Background()
+	account := decorators.Account{ID: "acc"}
+	if _, err := p.Charge(ctx, account, 42, time.Second, "secret"); err != nil {
+		t.Fatalf("Charge() = %v", err)
+	}
+	if _, err := p.Charge(ctx, account, 0, time.Second, "secret"); err == nil {
+		t.Fatal("Charge() succeeded, want error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	for key, want := range map[attribute.Key]attribute.Value{
+		"account.id": attribute.StringValue("acc"),
+		"amount":     attribute.Int64Value(42),
+		"timeout":    attribute.StringValue("1s"),
+		"receipt":    attribute.StringValue("acc-receipt"),
+	} {
+		if got, ok := attrs.Value(key); !ok || got != want {
+			t.Errorf("attribute %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+	if _, ok := attrs.Value("token"); ok {
+		t.Error("excluded attribute token was recorded")
+	}
+	if got := spans[1].Status().Code; got != codes.Error {
+		t.Errorf("failed span status = %v, want %v", got, codes.Error)
+	}
+}
+
+func TestTracedPaymentsImpl_ContextPosition(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	p := NewTracedPaymentsImpl(decorators.FakePayments{})
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if err := p.Capture("receipt", ctx); err != nil {
+		t.Fatalf("Capture() = %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if got, want := spans[0].Parent().SpanID(), parent.SpanContext().SpanID(); got != want {
+		t.Errorf("Capture span parent = %v, want %v", got, want)
+	}
+}
+
+func TestTracedPaymentsImpl_Link(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	p := NewTracedPaymentsImpl(decorators.FakePayments{})
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if err := p.Refund(&decorators.RefundRequest{Ctx: ctx}); err != nil {
+		t.Fatalf("Refund() = %v", err)
+	}
+	if err := p.Refund(nil); err != nil {
+		t.Fatalf("Refund() = %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(spans))
+	}
+	if spans[0].Parent().IsValid() {
+		t.Errorf("Refund span has parent %v, want a root span", spans[0].Parent())
+	}
+	if links := spans[0].Links(); len(links) != 1 || links[0].SpanContext.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Refund span links = %v, want a link to %v", links, parent.SpanContext())
+	}
+	if links := spans[1].Links(); len(links) != 0 {
+		t.Errorf("Refund(nil) span links = %v, want none", links)
+	}
+}
+
+func TestTracedStoreImpl_Panic(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	s := NewTracedStoreImpl(decorators.PanickingStore{}, WithTracedStoreImplTracerProvider(tp))
+
+	func() {
+		defer func() {
+			if r := recover(); r != "get" {
+				t.Errorf("recovered %v, want the panic of the delegate", r)
+			}
+		}()
+		_, _ = s.Get(context.Background(), "a")
+	}()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if got := spans[0].Status().Code; got != codes.Error {
+		t.Errorf("span status = %v, want %v", got, codes.Error)
+	}
+	var stacks int
+	for _, e := range spans[0].Events() {
+		attrs := attribute.NewSet(e.Attributes...)
+		if stack, ok := attrs.Value("exception.stacktrace"); ok && stack.AsString() != "" {
+			stacks++
+		}
+	}
+	if stacks != 1 {
+		t.Errorf("recorded %d events with a stack trace, want 1", stacks)
+	}
+}
+
+func TestTracedStoreImpl_ErrorClassifier(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	s := NewTracedStoreImpl(decorators.MemStore{},
+		WithTracedStoreImplTracerProvider(tp),
+		WithTracedStoreImplErrorClassifier(func(err error) bool {
+			return !errors.Is(err, decorators.ErrNotFound)
+		}))
+
+	if _, err := s.Get(context.Background(), "a"); err == nil {
+		t.Fatal("Get(a) succeeded, want error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if got := spans[0].Status().Code; got != codes.Unset {
+		t.Errorf("span status = %v, want %v", got, codes.Unset)
+	}
+}
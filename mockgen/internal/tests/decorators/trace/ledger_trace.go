@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ledger.go -destination=trace/ledger_trace.go -package trace -implementation_type=trace -trace_span_kind=client
+//
+
+// Package trace is a generated GoMock package.
+package trace
+
+import (
+	context "context"
+	fmt "fmt"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	otel "go.opentelemetry.io/otel"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+)
+
+// TracedLedgerImpl is a tracing decorator of Ledger interface.
+type TracedLedgerImpl struct {
+	delegate  decorators.Ledger
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+var _ decorators.Ledger = (*TracedLedgerImpl)(nil)
+
+// TracedLedgerImplOption configures a TracedLedgerImpl.
+type TracedLedgerImplOption func(*tracedLedgerImplOptions)
+
+type tracedLedgerImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedLedgerImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedLedgerImpl. Defaults to the global TracerProvider.
+func WithTracedLedgerImplTracerProvider(tp trace.TracerProvider) TracedLedgerImplOption {
+	return func(o *tracedLedgerImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedLedgerImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedLedgerImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedLedgerImplErrorClassifier(isFailure func(err error) bool) TracedLedgerImplOption {
+	return func(o *tracedLedgerImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedLedgerImpl creates a new trace decorator instance.
+func NewTracedLedgerImpl(ctrl decorators.Ledger, opts ...TracedLedgerImplOption) *TracedLedgerImpl {
+	o := tracedLedgerImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedLedgerImpl{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/decorators"), isFailure: o.isFailure}
+	return deco
+}
+
+// Append traced base method.
+func (t *TracedLedgerImpl) Append(c context.Context, entry string) *decorators.LedgerError {
+	c, span := t.tracer.Start(c, "Ledger.Append", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Append(c, entry)
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
@@ -0,0 +1,47 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+)
+
+func TestTracedLedgerImpl(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	l := NewTracedLedgerImpl(&decorators.MemLedger{}, WithTracedLedgerImplTracerProvider(tp))
+
+	ctx := context.Background()
+	if err := l.Append(ctx, "entry"); err != nil {
+		t.Fatalf("Append(entry) = %v", err)
+	}
+	if err := l.Append(ctx, ""); err == nil {
+		t.Fatal("Append() succeeded, want error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if got, want := spans[0].Name(), "Ledger.Append"; got != want {
+		t.Errorf("span name = %q, want %q", got, want)
+	}
+	if got, want := spans[0].SpanKind(), trace.SpanKindClient; got != want {
+		t.Errorf("span kind = %v, want %v", got, want)
+	}
+	if got, want := spans[0].InstrumentationScope().Name, "github.com/pableeee/implgen/mockgen/internal/tests/decorators"; got != want {
+		t.Errorf("instrumentation scope = %q, want %q", got, want)
+	}
+	if got := spans[0].Status().Code; got != codes.Unset {
+		t.Errorf("Append(entry) span status = %v, want %v", got, codes.Unset)
+	}
+	if got := spans[1].Status().Code; got != codes.Error {
+		t.Errorf("Append() span status = %v, want %v", got, codes.Error)
+	}
+}
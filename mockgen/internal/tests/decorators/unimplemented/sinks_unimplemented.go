@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sinks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sinks.go -destination=unimplemented/sinks_unimplemented.go -package unimplemented -implementation_type=unimplemented -unimplemented_guard
+//
+
+// Package unimplemented is a generated GoMock package.
+package unimplemented
+
+import (
+	context "context"
+
+	decorators "github.com/pableeee/implgen/mockgen/internal/tests/decorators"
+	unimplemented "github.com/pableeee/implgen/unimplemented"
+)
+
+// UnimplementedAuditSink is an implementation of AuditSink interface whose methods are not
+// implemented. It is embedded by the implementations that do not implement
+// every method, such as the methods added to the interface later.
+type UnimplementedAuditSink struct{}
+
+var _ decorators.AuditSink = (*UnimplementedAuditSink)(nil)
+
+// MustEmbedUnimplementedAuditSink is implemented by the implementations of AuditSink
+// embedding UnimplementedAuditSink, which APIs can require for the
+// implementations to stay compilable when methods are added to it.
+type MustEmbedUnimplementedAuditSink interface {
+	decorators.AuditSink
+	mustEmbedUnimplementedAuditSink()
+}
+
+func (UnimplementedAuditSink) mustEmbedUnimplementedAuditSink() {}
+
+// Events unimplemented base method.
+func (UnimplementedAuditSink) Events(ctx context.Context) (<-chan string, error) {
+	var ret <-chan string
+	return ret, &unimplemented.ErrNotImplemented{Interface: "AuditSink", Method: "Events"}
+}
+
+// Flush unimplemented base method.
+func (UnimplementedAuditSink) Flush() {
+	panic(&unimplemented.ErrNotImplemented{Interface: "AuditSink", Method: "Flush"})
+}
+
+// Record unimplemented base method.
+func (UnimplementedAuditSink) Record(ctx context.Context, event string) error {
+	return &unimplemented.ErrNotImplemented{Interface: "AuditSink", Method: "Record"}
+}
+
+// UnimplementedQueue is an implementation of Queue interface whose methods are not
+// implemented. It is embedded by the implementations that do not implement
+// every method, such as the methods added to the interface later.
+type UnimplementedQueue[T any] struct{}
+
+// MustEmbedUnimplementedQueue is implemented by the implementations of Queue
+// embedding UnimplementedQueue, which APIs can require for the
+// implementations to stay compilable when methods are added to it.
+type MustEmbedUnimplementedQueue[T any] interface {
+	decorators.Queue[T]
+	mustEmbedUnimplementedQueue()
+}
+
+func (UnimplementedQueue[T]) mustEmbedUnimplementedQueue() {}
+
+// Drain unimplemented base method.
+func (UnimplementedQueue[T]) Drain() <-chan T {
+	panic(&unimplemented.ErrNotImplemented{Interface: "Queue", Method: "Drain"})
+}
+
+// Pop unimplemented base method.
+func (UnimplementedQueue[T]) Pop() (T, bool) {
+	panic(&unimplemented.ErrNotImplemented{Interface: "Queue", Method: "Pop"})
+}
+
+// Push unimplemented base method.
+func (UnimplementedQueue[T]) Push(v T) error {
+	return &unimplemented.ErrNotImplemented{Interface: "Queue", Method: "Push"}
+}
@@ -0,0 +1,51 @@
+package unimplemented
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pableeee/implgen/unimplemented"
+)
+
+// fileSink is an AuditSink implementing only Record.
+type fileSink struct {
+	UnimplementedAuditSink
+	events []string
+}
+
+func (s *fileSink) Record(_ context.Context, event string) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+// The sinks embedding an UnimplementedAuditSink satisfy the guard.
+var _ MustEmbedUnimplementedAuditSink = (*fileSink)(nil)
+
+func TestUnimplementedAuditSink(t *testing.T) {
+	s := &fileSink{}
+	if err := s.Record(context.Background(), "login"); err != nil || len(s.events) != 1 {
+		t.Errorf("Record() = %v, want the event recorded", err)
+	}
+
+	_, err := s.Events(context.Background())
+	var notImplemented *unimplemented.ErrNotImplemented
+	if !errors.As(err, &notImplemented) || notImplemented.Interface != "AuditSink" || notImplemented.Method != "Events" {
+		t.Errorf("Events() = %v, want an *ErrNotImplemented of AuditSink.Events", err)
+	}
+
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, &unimplemented.ErrNotImplemented{Method: "Flush"}) {
+			t.Errorf("Flush() panicked with %v, want an *ErrNotImplemented of Flush", r)
+		}
+	}()
+	s.Flush()
+}
+
+func TestUnimplementedQueue(t *testing.T) {
+	var q UnimplementedQueue[int]
+	if err := q.Push(1); !errors.Is(err, &unimplemented.ErrNotImplemented{Interface: "Queue", Method: "Push"}) {
+		t.Errorf("Push() = %v, want an *ErrNotImplemented of Queue.Push", err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 )
 
 //go:generate mockgen --source=external.go --destination=source/mock_external_mock.go --package source
+//go:generate mockgen --source=external.go --destination=trace/external_trace.go --package trace --implementation_type=trace --trace_context_free=root
+//go:generate mockgen --source=external.go --destination=metrics/external_metrics.go --package metrics --implementation_type=metrics
 
 type ExternalConstraint[I constraints.Integer, F any] interface {
 	One(string) string
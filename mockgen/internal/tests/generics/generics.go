@@ -6,6 +6,8 @@ import (
 )
 
 //go:generate mockgen --source=generics.go --destination=source/mock_generics_mock.go --package source
+//go:generate mockgen --source=generics.go --destination=trace/generics_trace.go --package trace --implementation_type=trace --trace_context_free=root
+//go:generate mockgen --source=generics.go --destination=metrics/generics_metrics.go --package metrics --implementation_type=metrics
 ////go:generate mockgen --destination=reflect/mock_test.go --package reflect . Bar,Bar2
 
 type Bar[T any, R any] interface {
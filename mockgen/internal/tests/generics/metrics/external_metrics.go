@@ -0,0 +1,1086 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: external.go
+//
+// Generated by this command:
+//
+//	mockgen --source=external.go --destination=metrics/external_metrics.go --package metrics --implementation_type=metrics
+//
+
+// Package metrics is a generated GoMock package.
+package metrics
+
+import (
+	context "context"
+	time "time"
+
+	metrics "github.com/go-kit/kit/metrics"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	generics "github.com/pableeee/implgen/mockgen/internal/tests/generics"
+	other "github.com/pableeee/implgen/mockgen/internal/tests/generics/other"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	constraints "golang.org/x/exp/constraints"
+)
+
+// MetricsExternalConstraintImpl is a metrics decorator of ExternalConstraint interface.
+type MetricsExternalConstraintImpl[I constraints.Integer, F any] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.ExternalConstraint[I, F]
+	isFailure func(error) bool
+}
+
+// MetricsExternalConstraintImplOption configures a MetricsExternalConstraintImpl.
+type MetricsExternalConstraintImplOption func(*metricsExternalConstraintImplOptions)
+
+type metricsExternalConstraintImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsExternalConstraintImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsExternalConstraintImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsExternalConstraintImplErrorClassifier(isFailure func(err error) bool) MetricsExternalConstraintImplOption {
+	return func(o *metricsExternalConstraintImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsExternalConstraintImpl creates a new metrics decorator instance.
+func NewMetricsExternalConstraintImpl[I constraints.Integer, F any](ctrl generics.ExternalConstraint[I, F], opts ...MetricsExternalConstraintImplOption) *MetricsExternalConstraintImpl[I, F] {
+	o := metricsExternalConstraintImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsExternalConstraintImpl[I, F]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "ExternalConstraint",
+		Name:      "duration_seconds",
+		Help:      "Time spent in ExternalConstraint calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "ExternalConstraint",
+		Name:      "calls_total",
+		Help:      "Total number of ExternalConstraint calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "ExternalConstraint",
+		Name:      "errors_total",
+		Help:      "Total number of ExternalConstraint calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "ExternalConstraint",
+		Name:      "in_flight",
+		Help:      "Number of ExternalConstraint calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Eight Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Eight(arg0 F) other.Two[I, F] {
+	t.calls.With("method", "Eight").Add(1)
+	inFlight := t.inFlight.With("method", "Eight")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eight").Add(1)
+			t.duration.With("method", "Eight", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Eight(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Eight").Add(1)
+	}
+	t.duration.With("method", "Eight", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Eleven Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Eleven() map[string]I {
+	t.calls.With("method", "Eleven").Add(1)
+	inFlight := t.inFlight.With("method", "Eleven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eleven").Add(1)
+			t.duration.With("method", "Eleven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Eleven()
+	took := time.Since(begin)
+	t.duration.With("method", "Eleven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Five Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Five(arg0 I) generics.Baz[F] {
+	t.calls.With("method", "Five").Add(1)
+	inFlight := t.inFlight.With("method", "Five")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Five").Add(1)
+			t.duration.With("method", "Five", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Five(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Five").Add(1)
+	}
+	t.duration.With("method", "Five", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Four Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Four(arg0 I) generics.Foo[I, F] {
+	t.calls.With("method", "Four").Add(1)
+	inFlight := t.inFlight.With("method", "Four")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Four").Add(1)
+			t.duration.With("method", "Four", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Four(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Four").Add(1)
+	}
+	t.duration.With("method", "Four", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Nine Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Nine(arg0 generics.Iface[I]) {
+	t.calls.With("method", "Nine").Add(1)
+	inFlight := t.inFlight.With("method", "Nine")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Nine").Add(1)
+			t.duration.With("method", "Nine", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Nine(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Nine", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// One Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) One(arg0 string) string {
+	t.calls.With("method", "One").Add(1)
+	inFlight := t.inFlight.With("method", "One")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "One").Add(1)
+			t.duration.With("method", "One", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.One(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "One", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Seven Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Seven(arg0 I) other.One[I] {
+	t.calls.With("method", "Seven").Add(1)
+	inFlight := t.inFlight.With("method", "Seven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Seven").Add(1)
+			t.duration.With("method", "Seven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Seven(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Seven").Add(1)
+	}
+	t.duration.With("method", "Seven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Six Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Six(arg0 I) *generics.Baz[F] {
+	t.calls.With("method", "Six").Add(1)
+	inFlight := t.inFlight.With("method", "Six")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Six").Add(1)
+			t.duration.With("method", "Six", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Six(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Six").Add(1)
+	}
+	t.duration.With("method", "Six", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Ten Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Ten(arg0 *I) {
+	t.calls.With("method", "Ten").Add(1)
+	inFlight := t.inFlight.With("method", "Ten")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Ten").Add(1)
+			t.duration.With("method", "Ten", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Ten(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Ten", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// Thirteen Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Thirteen(arg0 ...I) *F {
+	t.calls.With("method", "Thirteen").Add(1)
+	inFlight := t.inFlight.With("method", "Thirteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Thirteen").Add(1)
+			t.duration.With("method", "Thirteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Thirteen(arg0...)
+	took := time.Since(begin)
+	t.duration.With("method", "Thirteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Three Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Three(arg0 I) F {
+	t.calls.With("method", "Three").Add(1)
+	inFlight := t.inFlight.With("method", "Three")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Three").Add(1)
+			t.duration.With("method", "Three", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Three(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Three", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Twelve Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Twelve(ctx context.Context) <-chan []I {
+	t.calls.With("method", "Twelve").Add(1)
+	inFlight := t.inFlight.With("method", "Twelve")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Twelve").Add(1)
+			t.duration.With("method", "Twelve", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Twelve(ctx)
+	took := time.Since(begin)
+	t.duration.With("method", "Twelve", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Two Metrics base method.
+func (t *MetricsExternalConstraintImpl[I, F]) Two(arg0 I) string {
+	t.calls.With("method", "Two").Add(1)
+	inFlight := t.inFlight.With("method", "Two")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Two").Add(1)
+			t.duration.With("method", "Two", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Two(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Two", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsEmbeddingIfaceImpl is a metrics decorator of EmbeddingIface interface.
+type MetricsEmbeddingIfaceImpl[T constraints.Integer, R constraints.Float] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.EmbeddingIface[T, R]
+	isFailure func(error) bool
+}
+
+// MetricsEmbeddingIfaceImplOption configures a MetricsEmbeddingIfaceImpl.
+type MetricsEmbeddingIfaceImplOption func(*metricsEmbeddingIfaceImplOptions)
+
+type metricsEmbeddingIfaceImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsEmbeddingIfaceImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsEmbeddingIfaceImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsEmbeddingIfaceImplErrorClassifier(isFailure func(err error) bool) MetricsEmbeddingIfaceImplOption {
+	return func(o *metricsEmbeddingIfaceImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsEmbeddingIfaceImpl creates a new metrics decorator instance.
+func NewMetricsEmbeddingIfaceImpl[T constraints.Integer, R constraints.Float](ctrl generics.EmbeddingIface[T, R], opts ...MetricsEmbeddingIfaceImplOption) *MetricsEmbeddingIfaceImpl[T, R] {
+	o := metricsEmbeddingIfaceImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsEmbeddingIfaceImpl[T, R]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "EmbeddingIface",
+		Name:      "duration_seconds",
+		Help:      "Time spent in EmbeddingIface calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "EmbeddingIface",
+		Name:      "calls_total",
+		Help:      "Total number of EmbeddingIface calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "EmbeddingIface",
+		Name:      "errors_total",
+		Help:      "Total number of EmbeddingIface calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "EmbeddingIface",
+		Name:      "in_flight",
+		Help:      "Number of EmbeddingIface calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Eight Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Eight(arg0 R) other.Two[T, R] {
+	t.calls.With("method", "Eight").Add(1)
+	inFlight := t.inFlight.With("method", "Eight")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eight").Add(1)
+			t.duration.With("method", "Eight", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Eight(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Eight").Add(1)
+	}
+	t.duration.With("method", "Eight", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Eleven Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Eleven() map[string]T {
+	t.calls.With("method", "Eleven").Add(1)
+	inFlight := t.inFlight.With("method", "Eleven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eleven").Add(1)
+			t.duration.With("method", "Eleven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Eleven()
+	took := time.Since(begin)
+	t.duration.With("method", "Eleven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// First Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) First() R {
+	t.calls.With("method", "First").Add(1)
+	inFlight := t.inFlight.With("method", "First")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "First").Add(1)
+			t.duration.With("method", "First", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.First()
+	took := time.Since(begin)
+	t.duration.With("method", "First", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Five Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Five(arg0 T) generics.Baz[R] {
+	t.calls.With("method", "Five").Add(1)
+	inFlight := t.inFlight.With("method", "Five")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Five").Add(1)
+			t.duration.With("method", "Five", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Five(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Five").Add(1)
+	}
+	t.duration.With("method", "Five", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Four Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Four(arg0 T) generics.Foo[T, R] {
+	t.calls.With("method", "Four").Add(1)
+	inFlight := t.inFlight.With("method", "Four")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Four").Add(1)
+			t.duration.With("method", "Four", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Four(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Four").Add(1)
+	}
+	t.duration.With("method", "Four", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Fourth Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Fourth() generics.Generator[T] {
+	t.calls.With("method", "Fourth").Add(1)
+	inFlight := t.inFlight.With("method", "Fourth")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Fourth").Add(1)
+			t.duration.With("method", "Fourth", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Fourth()
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Fourth").Add(1)
+	}
+	t.duration.With("method", "Fourth", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Generate Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Generate() R {
+	t.calls.With("method", "Generate").Add(1)
+	inFlight := t.inFlight.With("method", "Generate")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Generate").Add(1)
+			t.duration.With("method", "Generate", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Generate()
+	took := time.Since(begin)
+	t.duration.With("method", "Generate", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Nine Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Nine(arg0 generics.Iface[T]) {
+	t.calls.With("method", "Nine").Add(1)
+	inFlight := t.inFlight.With("method", "Nine")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Nine").Add(1)
+			t.duration.With("method", "Nine", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Nine(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Nine", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// One Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) One(arg0 string) string {
+	t.calls.With("method", "One").Add(1)
+	inFlight := t.inFlight.With("method", "One")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "One").Add(1)
+			t.duration.With("method", "One", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.One(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "One", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Read Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Read(p []byte) (int, error) {
+	t.calls.With("method", "Read").Add(1)
+	inFlight := t.inFlight.With("method", "Read")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Read").Add(1)
+			t.duration.With("method", "Read", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Read(p)
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Read").Add(1)
+	}
+	t.duration.With("method", "Read", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Second Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Second() generics.StructType {
+	t.calls.With("method", "Second").Add(1)
+	inFlight := t.inFlight.With("method", "Second")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Second").Add(1)
+			t.duration.With("method", "Second", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Second()
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Second").Add(1)
+	}
+	t.duration.With("method", "Second", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Seven Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Seven(arg0 T) other.One[T] {
+	t.calls.With("method", "Seven").Add(1)
+	inFlight := t.inFlight.With("method", "Seven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Seven").Add(1)
+			t.duration.With("method", "Seven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Seven(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Seven").Add(1)
+	}
+	t.duration.With("method", "Seven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Six Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Six(arg0 T) *generics.Baz[R] {
+	t.calls.With("method", "Six").Add(1)
+	inFlight := t.inFlight.With("method", "Six")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Six").Add(1)
+			t.duration.With("method", "Six", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Six(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Six").Add(1)
+	}
+	t.duration.With("method", "Six", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Ten Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Ten(arg0 *T) {
+	t.calls.With("method", "Ten").Add(1)
+	inFlight := t.inFlight.With("method", "Ten")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Ten").Add(1)
+			t.duration.With("method", "Ten", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Ten(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Ten", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// Third Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Third() other.Five {
+	t.calls.With("method", "Third").Add(1)
+	inFlight := t.inFlight.With("method", "Third")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Third").Add(1)
+			t.duration.With("method", "Third", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Third()
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Third").Add(1)
+	}
+	t.duration.With("method", "Third", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Thirteen Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Thirteen(arg0 ...T) *R {
+	t.calls.With("method", "Thirteen").Add(1)
+	inFlight := t.inFlight.With("method", "Thirteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Thirteen").Add(1)
+			t.duration.With("method", "Thirteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Thirteen(arg0...)
+	took := time.Since(begin)
+	t.duration.With("method", "Thirteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Three Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Three(arg0 T) R {
+	t.calls.With("method", "Three").Add(1)
+	inFlight := t.inFlight.With("method", "Three")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Three").Add(1)
+			t.duration.With("method", "Three", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Three(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Three", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Twelve Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Twelve(ctx context.Context) <-chan []T {
+	t.calls.With("method", "Twelve").Add(1)
+	inFlight := t.inFlight.With("method", "Twelve")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Twelve").Add(1)
+			t.duration.With("method", "Twelve", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Twelve(ctx)
+	took := time.Since(begin)
+	t.duration.With("method", "Twelve", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Two Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Two(arg0 T) string {
+	t.calls.With("method", "Two").Add(1)
+	inFlight := t.inFlight.With("method", "Two")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Two").Add(1)
+			t.duration.With("method", "Two", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Two(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Two", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Water Metrics base method.
+func (t *MetricsEmbeddingIfaceImpl[T, R]) Water(arg0 generics.Generator[T]) []generics.Generator[T] {
+	t.calls.With("method", "Water").Add(1)
+	inFlight := t.inFlight.With("method", "Water")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Water").Add(1)
+			t.duration.With("method", "Water", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Water(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Water", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsGeneratorImpl is a metrics decorator of Generator interface.
+type MetricsGeneratorImpl[T any] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Generator[T]
+	isFailure func(error) bool
+}
+
+// MetricsGeneratorImplOption configures a MetricsGeneratorImpl.
+type MetricsGeneratorImplOption func(*metricsGeneratorImplOptions)
+
+type metricsGeneratorImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsGeneratorImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsGeneratorImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsGeneratorImplErrorClassifier(isFailure func(err error) bool) MetricsGeneratorImplOption {
+	return func(o *metricsGeneratorImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsGeneratorImpl creates a new metrics decorator instance.
+func NewMetricsGeneratorImpl[T any](ctrl generics.Generator[T], opts ...MetricsGeneratorImplOption) *MetricsGeneratorImpl[T] {
+	o := metricsGeneratorImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsGeneratorImpl[T]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Generator",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Generator calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Generator",
+		Name:      "calls_total",
+		Help:      "Total number of Generator calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Generator",
+		Name:      "errors_total",
+		Help:      "Total number of Generator calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Generator",
+		Name:      "in_flight",
+		Help:      "Number of Generator calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Generate Metrics base method.
+func (t *MetricsGeneratorImpl[T]) Generate() T {
+	t.calls.With("method", "Generate").Add(1)
+	inFlight := t.inFlight.With("method", "Generate")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Generate").Add(1)
+			t.duration.With("method", "Generate", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Generate()
+	took := time.Since(begin)
+	t.duration.With("method", "Generate", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsGroupImpl is a metrics decorator of Group interface.
+type MetricsGroupImpl[T generics.Generator[any]] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Group[T]
+	isFailure func(error) bool
+}
+
+// MetricsGroupImplOption configures a MetricsGroupImpl.
+type MetricsGroupImplOption func(*metricsGroupImplOptions)
+
+type metricsGroupImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsGroupImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsGroupImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsGroupImplErrorClassifier(isFailure func(err error) bool) MetricsGroupImplOption {
+	return func(o *metricsGroupImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsGroupImpl creates a new metrics decorator instance.
+func NewMetricsGroupImpl[T generics.Generator[any]](ctrl generics.Group[T], opts ...MetricsGroupImplOption) *MetricsGroupImpl[T] {
+	o := metricsGroupImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsGroupImpl[T]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Group",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Group calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Group",
+		Name:      "calls_total",
+		Help:      "Total number of Group calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Group",
+		Name:      "errors_total",
+		Help:      "Total number of Group calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Group",
+		Name:      "in_flight",
+		Help:      "Number of Group calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Join Metrics base method.
+func (t *MetricsGroupImpl[T]) Join(ctx context.Context) []T {
+	t.calls.With("method", "Join").Add(1)
+	inFlight := t.inFlight.With("method", "Join")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Join").Add(1)
+			t.duration.With("method", "Join", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Join(ctx)
+	took := time.Since(begin)
+	t.duration.With("method", "Join", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
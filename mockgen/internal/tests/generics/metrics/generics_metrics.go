@@ -0,0 +1,959 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: generics.go
+//
+// Generated by this command:
+//
+//	mockgen --source=generics.go --destination=metrics/generics_metrics.go --package metrics --implementation_type=metrics
+//
+
+// Package metrics is a generated GoMock package.
+package metrics
+
+import (
+	time "time"
+
+	metrics "github.com/go-kit/kit/metrics"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	generics "github.com/pableeee/implgen/mockgen/internal/tests/generics"
+	other "github.com/pableeee/implgen/mockgen/internal/tests/generics/other"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	constraints "golang.org/x/exp/constraints"
+)
+
+// MetricsBarImpl is a metrics decorator of Bar interface.
+type MetricsBarImpl[T any, R any] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Bar[T, R]
+	isFailure func(error) bool
+}
+
+// MetricsBarImplOption configures a MetricsBarImpl.
+type MetricsBarImplOption func(*metricsBarImplOptions)
+
+type metricsBarImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsBarImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsBarImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsBarImplErrorClassifier(isFailure func(err error) bool) MetricsBarImplOption {
+	return func(o *metricsBarImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsBarImpl creates a new metrics decorator instance.
+func NewMetricsBarImpl[T any, R any](ctrl generics.Bar[T, R], opts ...MetricsBarImplOption) *MetricsBarImpl[T, R] {
+	o := metricsBarImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsBarImpl[T, R]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Bar",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Bar calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Bar",
+		Name:      "calls_total",
+		Help:      "Total number of Bar calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Bar",
+		Name:      "errors_total",
+		Help:      "Total number of Bar calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Bar",
+		Name:      "in_flight",
+		Help:      "Number of Bar calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Eight Metrics base method.
+func (t *MetricsBarImpl[T, R]) Eight(arg0 T) other.Two[T, R] {
+	t.calls.With("method", "Eight").Add(1)
+	inFlight := t.inFlight.With("method", "Eight")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eight").Add(1)
+			t.duration.With("method", "Eight", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Eight(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Eight").Add(1)
+	}
+	t.duration.With("method", "Eight", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Eighteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Eighteen() (generics.Iface[*other.Five], error) {
+	t.calls.With("method", "Eighteen").Add(1)
+	inFlight := t.inFlight.With("method", "Eighteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eighteen").Add(1)
+			t.duration.With("method", "Eighteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Eighteen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Eighteen").Add(1)
+	}
+	t.duration.With("method", "Eighteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Eleven Metrics base method.
+func (t *MetricsBarImpl[T, R]) Eleven() (*other.One[T], error) {
+	t.calls.With("method", "Eleven").Add(1)
+	inFlight := t.inFlight.With("method", "Eleven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Eleven").Add(1)
+			t.duration.With("method", "Eleven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Eleven()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Eleven").Add(1)
+	}
+	t.duration.With("method", "Eleven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Fifteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Fifteen() (generics.Iface[generics.StructType], error) {
+	t.calls.With("method", "Fifteen").Add(1)
+	inFlight := t.inFlight.With("method", "Fifteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Fifteen").Add(1)
+			t.duration.With("method", "Fifteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Fifteen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Fifteen").Add(1)
+	}
+	t.duration.With("method", "Fifteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Five Metrics base method.
+func (t *MetricsBarImpl[T, R]) Five(arg0 T) generics.Baz[T] {
+	t.calls.With("method", "Five").Add(1)
+	inFlight := t.inFlight.With("method", "Five")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Five").Add(1)
+			t.duration.With("method", "Five", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Five(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Five").Add(1)
+	}
+	t.duration.With("method", "Five", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Four Metrics base method.
+func (t *MetricsBarImpl[T, R]) Four(arg0 T) generics.Foo[T, R] {
+	t.calls.With("method", "Four").Add(1)
+	inFlight := t.inFlight.With("method", "Four")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Four").Add(1)
+			t.duration.With("method", "Four", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Four(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Four").Add(1)
+	}
+	t.duration.With("method", "Four", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Fourteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Fourteen() (*generics.Foo[generics.StructType, generics.StructType2], error) {
+	t.calls.With("method", "Fourteen").Add(1)
+	inFlight := t.inFlight.With("method", "Fourteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Fourteen").Add(1)
+			t.duration.With("method", "Fourteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Fourteen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Fourteen").Add(1)
+	}
+	t.duration.With("method", "Fourteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Nine Metrics base method.
+func (t *MetricsBarImpl[T, R]) Nine(arg0 generics.Iface[T]) {
+	t.calls.With("method", "Nine").Add(1)
+	inFlight := t.inFlight.With("method", "Nine")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Nine").Add(1)
+			t.duration.With("method", "Nine", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Nine(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Nine", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// Nineteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Nineteen() generics.AliasType {
+	t.calls.With("method", "Nineteen").Add(1)
+	inFlight := t.inFlight.With("method", "Nineteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Nineteen").Add(1)
+			t.duration.With("method", "Nineteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Nineteen()
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Nineteen").Add(1)
+	}
+	t.duration.With("method", "Nineteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// One Metrics base method.
+func (t *MetricsBarImpl[T, R]) One(arg0 string) string {
+	t.calls.With("method", "One").Add(1)
+	inFlight := t.inFlight.With("method", "One")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "One").Add(1)
+			t.duration.With("method", "One", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.One(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "One", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Seven Metrics base method.
+func (t *MetricsBarImpl[T, R]) Seven(arg0 T) other.One[T] {
+	t.calls.With("method", "Seven").Add(1)
+	inFlight := t.inFlight.With("method", "Seven")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Seven").Add(1)
+			t.duration.With("method", "Seven", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Seven(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Seven").Add(1)
+	}
+	t.duration.With("method", "Seven", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Seventeen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Seventeen() (*generics.Foo[other.Three, other.Four], error) {
+	t.calls.With("method", "Seventeen").Add(1)
+	inFlight := t.inFlight.With("method", "Seventeen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Seventeen").Add(1)
+			t.duration.With("method", "Seventeen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Seventeen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Seventeen").Add(1)
+	}
+	t.duration.With("method", "Seventeen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Six Metrics base method.
+func (t *MetricsBarImpl[T, R]) Six(arg0 T) *generics.Baz[T] {
+	t.calls.With("method", "Six").Add(1)
+	inFlight := t.inFlight.With("method", "Six")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Six").Add(1)
+			t.duration.With("method", "Six", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Six(arg0)
+	took := time.Since(begin)
+	failed = "false"
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		failed = "true"
+		t.errors.With("method", "Six").Add(1)
+	}
+	t.duration.With("method", "Six", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Sixteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Sixteen() (generics.Baz[other.Three], error) {
+	t.calls.With("method", "Sixteen").Add(1)
+	inFlight := t.inFlight.With("method", "Sixteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Sixteen").Add(1)
+			t.duration.With("method", "Sixteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Sixteen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Sixteen").Add(1)
+	}
+	t.duration.With("method", "Sixteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Ten Metrics base method.
+func (t *MetricsBarImpl[T, R]) Ten(arg0 *T) {
+	t.calls.With("method", "Ten").Add(1)
+	inFlight := t.inFlight.With("method", "Ten")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Ten").Add(1)
+			t.duration.With("method", "Ten", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	t.delegate.Ten(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Ten", "error", failed, "panic", "false").Observe(took.Seconds())
+}
+
+// Thirteen Metrics base method.
+func (t *MetricsBarImpl[T, R]) Thirteen() (generics.Baz[generics.StructType], error) {
+	t.calls.With("method", "Thirteen").Add(1)
+	inFlight := t.inFlight.With("method", "Thirteen")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Thirteen").Add(1)
+			t.duration.With("method", "Thirteen", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Thirteen()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Thirteen").Add(1)
+	}
+	t.duration.With("method", "Thirteen", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Three Metrics base method.
+func (t *MetricsBarImpl[T, R]) Three(arg0 T) R {
+	t.calls.With("method", "Three").Add(1)
+	inFlight := t.inFlight.With("method", "Three")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Three").Add(1)
+			t.duration.With("method", "Three", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Three(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Three", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// Twelve Metrics base method.
+func (t *MetricsBarImpl[T, R]) Twelve() (*other.Two[T, R], error) {
+	t.calls.With("method", "Twelve").Add(1)
+	inFlight := t.inFlight.With("method", "Twelve")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Twelve").Add(1)
+			t.duration.With("method", "Twelve", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret, ret_2 := t.delegate.Twelve()
+	took := time.Since(begin)
+	failed = "false"
+	if ret_2 != nil && t.isFailure(ret_2) {
+		failed = "true"
+		t.errors.With("method", "Twelve").Add(1)
+	}
+	t.duration.With("method", "Twelve", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret, ret_2
+}
+
+// Two Metrics base method.
+func (t *MetricsBarImpl[T, R]) Two(arg0 T) string {
+	t.calls.With("method", "Two").Add(1)
+	inFlight := t.inFlight.With("method", "Two")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Two").Add(1)
+			t.duration.With("method", "Two", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Two(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Two", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsUniverseImpl is a metrics decorator of Universe interface.
+type MetricsUniverseImpl[T constraints.Signed] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Universe[T]
+	isFailure func(error) bool
+}
+
+// MetricsUniverseImplOption configures a MetricsUniverseImpl.
+type MetricsUniverseImplOption func(*metricsUniverseImplOptions)
+
+type metricsUniverseImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsUniverseImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsUniverseImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsUniverseImplErrorClassifier(isFailure func(err error) bool) MetricsUniverseImplOption {
+	return func(o *metricsUniverseImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsUniverseImpl creates a new metrics decorator instance.
+func NewMetricsUniverseImpl[T constraints.Signed](ctrl generics.Universe[T], opts ...MetricsUniverseImplOption) *MetricsUniverseImpl[T] {
+	o := metricsUniverseImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsUniverseImpl[T]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Universe",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Universe calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Universe",
+		Name:      "calls_total",
+		Help:      "Total number of Universe calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Universe",
+		Name:      "errors_total",
+		Help:      "Total number of Universe calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Universe",
+		Name:      "in_flight",
+		Help:      "Number of Universe calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Water Metrics base method.
+func (t *MetricsUniverseImpl[T]) Water(arg0 T) []T {
+	t.calls.With("method", "Water").Add(1)
+	inFlight := t.inFlight.With("method", "Water")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Water").Add(1)
+			t.duration.With("method", "Water", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Water(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Water", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsMilkyWayImpl is a metrics decorator of MilkyWay interface.
+type MetricsMilkyWayImpl[R constraints.Integer] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.MilkyWay[R]
+	isFailure func(error) bool
+}
+
+// MetricsMilkyWayImplOption configures a MetricsMilkyWayImpl.
+type MetricsMilkyWayImplOption func(*metricsMilkyWayImplOptions)
+
+type metricsMilkyWayImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsMilkyWayImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsMilkyWayImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsMilkyWayImplErrorClassifier(isFailure func(err error) bool) MetricsMilkyWayImplOption {
+	return func(o *metricsMilkyWayImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsMilkyWayImpl creates a new metrics decorator instance.
+func NewMetricsMilkyWayImpl[R constraints.Integer](ctrl generics.MilkyWay[R], opts ...MetricsMilkyWayImplOption) *MetricsMilkyWayImpl[R] {
+	o := metricsMilkyWayImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsMilkyWayImpl[R]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "MilkyWay",
+		Name:      "duration_seconds",
+		Help:      "Time spent in MilkyWay calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "MilkyWay",
+		Name:      "calls_total",
+		Help:      "Total number of MilkyWay calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "MilkyWay",
+		Name:      "errors_total",
+		Help:      "Total number of MilkyWay calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "MilkyWay",
+		Name:      "in_flight",
+		Help:      "Number of MilkyWay calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Water Metrics base method.
+func (t *MetricsMilkyWayImpl[R]) Water(arg0 R) []R {
+	t.calls.With("method", "Water").Add(1)
+	inFlight := t.inFlight.With("method", "Water")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Water").Add(1)
+			t.duration.With("method", "Water", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Water(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Water", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsSolarSystemImpl is a metrics decorator of SolarSystem interface.
+type MetricsSolarSystemImpl[T constraints.Ordered] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.SolarSystem[T]
+	isFailure func(error) bool
+}
+
+// MetricsSolarSystemImplOption configures a MetricsSolarSystemImpl.
+type MetricsSolarSystemImplOption func(*metricsSolarSystemImplOptions)
+
+type metricsSolarSystemImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsSolarSystemImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsSolarSystemImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsSolarSystemImplErrorClassifier(isFailure func(err error) bool) MetricsSolarSystemImplOption {
+	return func(o *metricsSolarSystemImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsSolarSystemImpl creates a new metrics decorator instance.
+func NewMetricsSolarSystemImpl[T constraints.Ordered](ctrl generics.SolarSystem[T], opts ...MetricsSolarSystemImplOption) *MetricsSolarSystemImpl[T] {
+	o := metricsSolarSystemImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsSolarSystemImpl[T]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "SolarSystem",
+		Name:      "duration_seconds",
+		Help:      "Time spent in SolarSystem calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "SolarSystem",
+		Name:      "calls_total",
+		Help:      "Total number of SolarSystem calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "SolarSystem",
+		Name:      "errors_total",
+		Help:      "Total number of SolarSystem calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "SolarSystem",
+		Name:      "in_flight",
+		Help:      "Number of SolarSystem calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Water Metrics base method.
+func (t *MetricsSolarSystemImpl[T]) Water(arg0 T) []T {
+	t.calls.With("method", "Water").Add(1)
+	inFlight := t.inFlight.With("method", "Water")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Water").Add(1)
+			t.duration.With("method", "Water", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Water(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Water", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsEarthImpl is a metrics decorator of Earth interface.
+type MetricsEarthImpl[R any] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Earth[R]
+	isFailure func(error) bool
+}
+
+// MetricsEarthImplOption configures a MetricsEarthImpl.
+type MetricsEarthImplOption func(*metricsEarthImplOptions)
+
+type metricsEarthImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsEarthImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsEarthImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsEarthImplErrorClassifier(isFailure func(err error) bool) MetricsEarthImplOption {
+	return func(o *metricsEarthImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsEarthImpl creates a new metrics decorator instance.
+func NewMetricsEarthImpl[R any](ctrl generics.Earth[R], opts ...MetricsEarthImplOption) *MetricsEarthImpl[R] {
+	o := metricsEarthImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsEarthImpl[R]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Earth",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Earth calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Earth",
+		Name:      "calls_total",
+		Help:      "Total number of Earth calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Earth",
+		Name:      "errors_total",
+		Help:      "Total number of Earth calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Earth",
+		Name:      "in_flight",
+		Help:      "Number of Earth calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Water Metrics base method.
+func (t *MetricsEarthImpl[R]) Water(arg0 R) []R {
+	t.calls.With("method", "Water").Add(1)
+	inFlight := t.inFlight.With("method", "Water")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Water").Add(1)
+			t.duration.With("method", "Water", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Water(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Water", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
+
+// MetricsWaterImpl is a metrics decorator of Water interface.
+type MetricsWaterImpl[R any, C generics.UnsignedInteger] struct {
+	duration  metrics.Histogram
+	calls     metrics.Counter
+	errors    metrics.Counter
+	inFlight  metrics.Gauge
+	delegate  generics.Water[R, C]
+	isFailure func(error) bool
+}
+
+// MetricsWaterImplOption configures a MetricsWaterImpl.
+type MetricsWaterImplOption func(*metricsWaterImplOptions)
+
+type metricsWaterImplOptions struct {
+	isFailure func(err error) bool
+}
+
+// WithMetricsWaterImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a MetricsWaterImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithMetricsWaterImplErrorClassifier(isFailure func(err error) bool) MetricsWaterImplOption {
+	return func(o *metricsWaterImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewMetricsWaterImpl creates a new metrics decorator instance.
+func NewMetricsWaterImpl[R any, C generics.UnsignedInteger](ctrl generics.Water[R, C], opts ...MetricsWaterImplOption) *MetricsWaterImpl[R, C] {
+	o := metricsWaterImplOptions{isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &MetricsWaterImpl[R, C]{delegate: ctrl, isFailure: o.isFailure}
+	deco.duration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Subsystem: "Water",
+		Name:      "duration_seconds",
+		Help:      "Time spent in Water calls, in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "error", "panic"})
+	deco.calls = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Water",
+		Name:      "calls_total",
+		Help:      "Total number of Water calls.",
+	}, []string{"method"})
+	deco.errors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Subsystem: "Water",
+		Name:      "errors_total",
+		Help:      "Total number of Water calls that returned an error.",
+	}, []string{"method"})
+	deco.inFlight = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Subsystem: "Water",
+		Name:      "in_flight",
+		Help:      "Number of Water calls currently in flight.",
+	}, []string{"method"})
+	return deco
+}
+
+// Fish Metrics base method.
+func (t *MetricsWaterImpl[R, C]) Fish(arg0 R) []C {
+	t.calls.With("method", "Fish").Add(1)
+	inFlight := t.inFlight.With("method", "Fish")
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+	begin := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			took := time.Since(begin)
+			t.errors.With("method", "Fish").Add(1)
+			t.duration.With("method", "Fish", "error", "true", "panic", "true").Observe(took.Seconds())
+			panic(r)
+		}
+	}()
+	failed := "N/A"
+	ret := t.delegate.Fish(arg0)
+	took := time.Since(begin)
+	t.duration.With("method", "Fish", "error", failed, "panic", "false").Observe(took.Seconds())
+	return ret
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/generics"
+)
+
+type earth []int
+
+func (e earth) Water(r int) []int { return append(e, r) }
+
+func TestMetricsEarthImpl(t *testing.T) {
+	var e generics.Earth[int] = NewMetricsEarthImpl[int](earth{1})
+
+	if got, want := e.Water(2), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Water(2) = %v, want %v", got, want)
+	}
+}
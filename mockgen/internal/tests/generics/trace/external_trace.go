@@ -0,0 +1,767 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: external.go
+//
+// Generated by this command:
+//
+//	mockgen --source=external.go --destination=trace/external_trace.go --package trace --implementation_type=trace --trace_context_free=root
+//
+
+// Package trace is a generated GoMock package.
+package trace
+
+import (
+	context "context"
+	fmt "fmt"
+
+	generics "github.com/pableeee/implgen/mockgen/internal/tests/generics"
+	other "github.com/pableeee/implgen/mockgen/internal/tests/generics/other"
+	otel "go.opentelemetry.io/otel"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+	constraints "golang.org/x/exp/constraints"
+)
+
+// TracedExternalConstraintImpl is a tracing decorator of ExternalConstraint interface.
+type TracedExternalConstraintImpl[I constraints.Integer, F any] struct {
+	delegate  generics.ExternalConstraint[I, F]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedExternalConstraintImplOption configures a TracedExternalConstraintImpl.
+type TracedExternalConstraintImplOption func(*tracedExternalConstraintImplOptions)
+
+type tracedExternalConstraintImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedExternalConstraintImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedExternalConstraintImpl. Defaults to the global TracerProvider.
+func WithTracedExternalConstraintImplTracerProvider(tp trace.TracerProvider) TracedExternalConstraintImplOption {
+	return func(o *tracedExternalConstraintImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedExternalConstraintImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedExternalConstraintImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedExternalConstraintImplErrorClassifier(isFailure func(err error) bool) TracedExternalConstraintImplOption {
+	return func(o *tracedExternalConstraintImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedExternalConstraintImpl creates a new trace decorator instance.
+func NewTracedExternalConstraintImpl[I constraints.Integer, F any](ctrl generics.ExternalConstraint[I, F], opts ...TracedExternalConstraintImplOption) *TracedExternalConstraintImpl[I, F] {
+	o := tracedExternalConstraintImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedExternalConstraintImpl[I, F]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Eight traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Eight(arg0 F) other.Two[I, F] {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Eight")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Eight(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Eleven traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Eleven() map[string]I {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Eleven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Eleven()
+	return ret
+}
+
+// Five traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Five(arg0 I) generics.Baz[F] {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Five")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Five(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Four traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Four(arg0 I) generics.Foo[I, F] {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Four")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Four(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Nine traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Nine(arg0 generics.Iface[I]) {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Nine")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Nine(arg0)
+}
+
+// One traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) One(arg0 string) string {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.One")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.One(arg0)
+	return ret
+}
+
+// Seven traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Seven(arg0 I) other.One[I] {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Seven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Seven(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Six traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Six(arg0 I) *generics.Baz[F] {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Six")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Six(arg0)
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Ten traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Ten(arg0 *I) {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Ten")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Ten(arg0)
+}
+
+// Thirteen traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Thirteen(arg0 ...I) *F {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Thirteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Thirteen(arg0...)
+	return ret
+}
+
+// Three traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Three(arg0 I) F {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Three")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Three(arg0)
+	return ret
+}
+
+// Twelve traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Twelve(ctx context.Context) <-chan []I {
+	ctx, span := t.tracer.Start(ctx, "ExternalConstraint.Twelve")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Twelve(ctx)
+	return ret
+}
+
+// Two traced base method.
+func (t *TracedExternalConstraintImpl[I, F]) Two(arg0 I) string {
+	_, span := t.tracer.Start(context.Background(), "ExternalConstraint.Two")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Two(arg0)
+	return ret
+}
+
+// TracedEmbeddingIfaceImpl is a tracing decorator of EmbeddingIface interface.
+type TracedEmbeddingIfaceImpl[T constraints.Integer, R constraints.Float] struct {
+	delegate  generics.EmbeddingIface[T, R]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedEmbeddingIfaceImplOption configures a TracedEmbeddingIfaceImpl.
+type TracedEmbeddingIfaceImplOption func(*tracedEmbeddingIfaceImplOptions)
+
+type tracedEmbeddingIfaceImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedEmbeddingIfaceImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedEmbeddingIfaceImpl. Defaults to the global TracerProvider.
+func WithTracedEmbeddingIfaceImplTracerProvider(tp trace.TracerProvider) TracedEmbeddingIfaceImplOption {
+	return func(o *tracedEmbeddingIfaceImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedEmbeddingIfaceImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedEmbeddingIfaceImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedEmbeddingIfaceImplErrorClassifier(isFailure func(err error) bool) TracedEmbeddingIfaceImplOption {
+	return func(o *tracedEmbeddingIfaceImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedEmbeddingIfaceImpl creates a new trace decorator instance.
+func NewTracedEmbeddingIfaceImpl[T constraints.Integer, R constraints.Float](ctrl generics.EmbeddingIface[T, R], opts ...TracedEmbeddingIfaceImplOption) *TracedEmbeddingIfaceImpl[T, R] {
+	o := tracedEmbeddingIfaceImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedEmbeddingIfaceImpl[T, R]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Eight traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Eight(arg0 R) other.Two[T, R] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Eight")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Eight(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Eleven traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Eleven() map[string]T {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Eleven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Eleven()
+	return ret
+}
+
+// First traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) First() R {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.First")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.First()
+	return ret
+}
+
+// Five traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Five(arg0 T) generics.Baz[R] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Five")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Five(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Four traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Four(arg0 T) generics.Foo[T, R] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Four")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Four(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Fourth traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Fourth() generics.Generator[T] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Fourth")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Fourth()
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Generate traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Generate() R {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Generate")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Generate()
+	return ret
+}
+
+// Nine traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Nine(arg0 generics.Iface[T]) {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Nine")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Nine(arg0)
+}
+
+// One traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) One(arg0 string) string {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.One")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.One(arg0)
+	return ret
+}
+
+// Read traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Read(p []byte) (int, error) {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Read")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Read(p)
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Second traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Second() generics.StructType {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Second")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Second()
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Seven traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Seven(arg0 T) other.One[T] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Seven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Seven(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Six traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Six(arg0 T) *generics.Baz[R] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Six")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Six(arg0)
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Ten traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Ten(arg0 *T) {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Ten")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Ten(arg0)
+}
+
+// Third traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Third() other.Five {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Third")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Third()
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Thirteen traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Thirteen(arg0 ...T) *R {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Thirteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Thirteen(arg0...)
+	return ret
+}
+
+// Three traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Three(arg0 T) R {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Three")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Three(arg0)
+	return ret
+}
+
+// Twelve traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Twelve(ctx context.Context) <-chan []T {
+	ctx, span := t.tracer.Start(ctx, "EmbeddingIface.Twelve")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Twelve(ctx)
+	return ret
+}
+
+// Two traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Two(arg0 T) string {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Two")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Two(arg0)
+	return ret
+}
+
+// Water traced base method.
+func (t *TracedEmbeddingIfaceImpl[T, R]) Water(arg0 generics.Generator[T]) []generics.Generator[T] {
+	_, span := t.tracer.Start(context.Background(), "EmbeddingIface.Water")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Water(arg0)
+	return ret
+}
+
+// TracedGeneratorImpl is a tracing decorator of Generator interface.
+type TracedGeneratorImpl[T any] struct {
+	delegate  generics.Generator[T]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedGeneratorImplOption configures a TracedGeneratorImpl.
+type TracedGeneratorImplOption func(*tracedGeneratorImplOptions)
+
+type tracedGeneratorImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedGeneratorImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedGeneratorImpl. Defaults to the global TracerProvider.
+func WithTracedGeneratorImplTracerProvider(tp trace.TracerProvider) TracedGeneratorImplOption {
+	return func(o *tracedGeneratorImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedGeneratorImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedGeneratorImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedGeneratorImplErrorClassifier(isFailure func(err error) bool) TracedGeneratorImplOption {
+	return func(o *tracedGeneratorImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedGeneratorImpl creates a new trace decorator instance.
+func NewTracedGeneratorImpl[T any](ctrl generics.Generator[T], opts ...TracedGeneratorImplOption) *TracedGeneratorImpl[T] {
+	o := tracedGeneratorImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedGeneratorImpl[T]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Generate traced base method.
+func (t *TracedGeneratorImpl[T]) Generate() T {
+	_, span := t.tracer.Start(context.Background(), "Generator.Generate")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Generate()
+	return ret
+}
+
+// TracedGroupImpl is a tracing decorator of Group interface.
+type TracedGroupImpl[T generics.Generator[any]] struct {
+	delegate  generics.Group[T]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedGroupImplOption configures a TracedGroupImpl.
+type TracedGroupImplOption func(*tracedGroupImplOptions)
+
+type tracedGroupImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedGroupImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedGroupImpl. Defaults to the global TracerProvider.
+func WithTracedGroupImplTracerProvider(tp trace.TracerProvider) TracedGroupImplOption {
+	return func(o *tracedGroupImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedGroupImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedGroupImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedGroupImplErrorClassifier(isFailure func(err error) bool) TracedGroupImplOption {
+	return func(o *tracedGroupImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedGroupImpl creates a new trace decorator instance.
+func NewTracedGroupImpl[T generics.Generator[any]](ctrl generics.Group[T], opts ...TracedGroupImplOption) *TracedGroupImpl[T] {
+	o := tracedGroupImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedGroupImpl[T]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Join traced base method.
+func (t *TracedGroupImpl[T]) Join(ctx context.Context) []T {
+	ctx, span := t.tracer.Start(ctx, "Group.Join")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Join(ctx)
+	return ret
+}
@@ -0,0 +1,688 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: generics.go
+//
+// Generated by this command:
+//
+//	mockgen --source=generics.go --destination=trace/generics_trace.go --package trace --implementation_type=trace --trace_context_free=root
+//
+
+// Package trace is a generated GoMock package.
+package trace
+
+import (
+	context "context"
+	fmt "fmt"
+
+	generics "github.com/pableeee/implgen/mockgen/internal/tests/generics"
+	other "github.com/pableeee/implgen/mockgen/internal/tests/generics/other"
+	otel "go.opentelemetry.io/otel"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+	constraints "golang.org/x/exp/constraints"
+)
+
+// TracedBarImpl is a tracing decorator of Bar interface.
+type TracedBarImpl[T any, R any] struct {
+	delegate  generics.Bar[T, R]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedBarImplOption configures a TracedBarImpl.
+type TracedBarImplOption func(*tracedBarImplOptions)
+
+type tracedBarImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedBarImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedBarImpl. Defaults to the global TracerProvider.
+func WithTracedBarImplTracerProvider(tp trace.TracerProvider) TracedBarImplOption {
+	return func(o *tracedBarImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedBarImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedBarImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedBarImplErrorClassifier(isFailure func(err error) bool) TracedBarImplOption {
+	return func(o *tracedBarImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedBarImpl creates a new trace decorator instance.
+func NewTracedBarImpl[T any, R any](ctrl generics.Bar[T, R], opts ...TracedBarImplOption) *TracedBarImpl[T, R] {
+	o := tracedBarImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedBarImpl[T, R]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Eight traced base method.
+func (t *TracedBarImpl[T, R]) Eight(arg0 T) other.Two[T, R] {
+	_, span := t.tracer.Start(context.Background(), "Bar.Eight")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Eight(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Eighteen traced base method.
+func (t *TracedBarImpl[T, R]) Eighteen() (generics.Iface[*other.Five], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Eighteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Eighteen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Eleven traced base method.
+func (t *TracedBarImpl[T, R]) Eleven() (*other.One[T], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Eleven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Eleven()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Fifteen traced base method.
+func (t *TracedBarImpl[T, R]) Fifteen() (generics.Iface[generics.StructType], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Fifteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Fifteen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Five traced base method.
+func (t *TracedBarImpl[T, R]) Five(arg0 T) generics.Baz[T] {
+	_, span := t.tracer.Start(context.Background(), "Bar.Five")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Five(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Four traced base method.
+func (t *TracedBarImpl[T, R]) Four(arg0 T) generics.Foo[T, R] {
+	_, span := t.tracer.Start(context.Background(), "Bar.Four")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Four(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Fourteen traced base method.
+func (t *TracedBarImpl[T, R]) Fourteen() (*generics.Foo[generics.StructType, generics.StructType2], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Fourteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Fourteen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Nine traced base method.
+func (t *TracedBarImpl[T, R]) Nine(arg0 generics.Iface[T]) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Nine")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Nine(arg0)
+}
+
+// Nineteen traced base method.
+func (t *TracedBarImpl[T, R]) Nineteen() generics.AliasType {
+	_, span := t.tracer.Start(context.Background(), "Bar.Nineteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Nineteen()
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// One traced base method.
+func (t *TracedBarImpl[T, R]) One(arg0 string) string {
+	_, span := t.tracer.Start(context.Background(), "Bar.One")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.One(arg0)
+	return ret
+}
+
+// Seven traced base method.
+func (t *TracedBarImpl[T, R]) Seven(arg0 T) other.One[T] {
+	_, span := t.tracer.Start(context.Background(), "Bar.Seven")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Seven(arg0)
+	if err, ok := any(ret).(error); ok && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Seventeen traced base method.
+func (t *TracedBarImpl[T, R]) Seventeen() (*generics.Foo[other.Three, other.Four], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Seventeen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Seventeen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Six traced base method.
+func (t *TracedBarImpl[T, R]) Six(arg0 T) *generics.Baz[T] {
+	_, span := t.tracer.Start(context.Background(), "Bar.Six")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Six(arg0)
+	if err, ok := any(ret).(error); ok && ret != nil && t.isFailure(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret
+}
+
+// Sixteen traced base method.
+func (t *TracedBarImpl[T, R]) Sixteen() (generics.Baz[other.Three], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Sixteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Sixteen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Ten traced base method.
+func (t *TracedBarImpl[T, R]) Ten(arg0 *T) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Ten")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	t.delegate.Ten(arg0)
+}
+
+// Thirteen traced base method.
+func (t *TracedBarImpl[T, R]) Thirteen() (generics.Baz[generics.StructType], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Thirteen")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Thirteen()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Three traced base method.
+func (t *TracedBarImpl[T, R]) Three(arg0 T) R {
+	_, span := t.tracer.Start(context.Background(), "Bar.Three")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Three(arg0)
+	return ret
+}
+
+// Twelve traced base method.
+func (t *TracedBarImpl[T, R]) Twelve() (*other.Two[T, R], error) {
+	_, span := t.tracer.Start(context.Background(), "Bar.Twelve")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret, ret_2 := t.delegate.Twelve()
+	if ret_2 != nil && t.isFailure(ret_2) {
+		span.RecordError(ret_2)
+		span.SetStatus(codes.Error, ret_2.Error())
+	}
+	return ret, ret_2
+}
+
+// Two traced base method.
+func (t *TracedBarImpl[T, R]) Two(arg0 T) string {
+	_, span := t.tracer.Start(context.Background(), "Bar.Two")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Two(arg0)
+	return ret
+}
+
+// TracedUniverseImpl is a tracing decorator of Universe interface.
+type TracedUniverseImpl[T constraints.Signed] struct {
+	delegate  generics.Universe[T]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedUniverseImplOption configures a TracedUniverseImpl.
+type TracedUniverseImplOption func(*tracedUniverseImplOptions)
+
+type tracedUniverseImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedUniverseImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedUniverseImpl. Defaults to the global TracerProvider.
+func WithTracedUniverseImplTracerProvider(tp trace.TracerProvider) TracedUniverseImplOption {
+	return func(o *tracedUniverseImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedUniverseImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedUniverseImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedUniverseImplErrorClassifier(isFailure func(err error) bool) TracedUniverseImplOption {
+	return func(o *tracedUniverseImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedUniverseImpl creates a new trace decorator instance.
+func NewTracedUniverseImpl[T constraints.Signed](ctrl generics.Universe[T], opts ...TracedUniverseImplOption) *TracedUniverseImpl[T] {
+	o := tracedUniverseImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedUniverseImpl[T]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Water traced base method.
+func (t *TracedUniverseImpl[T]) Water(arg0 T) []T {
+	_, span := t.tracer.Start(context.Background(), "Universe.Water")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Water(arg0)
+	return ret
+}
+
+// TracedMilkyWayImpl is a tracing decorator of MilkyWay interface.
+type TracedMilkyWayImpl[R constraints.Integer] struct {
+	delegate  generics.MilkyWay[R]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedMilkyWayImplOption configures a TracedMilkyWayImpl.
+type TracedMilkyWayImplOption func(*tracedMilkyWayImplOptions)
+
+type tracedMilkyWayImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedMilkyWayImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedMilkyWayImpl. Defaults to the global TracerProvider.
+func WithTracedMilkyWayImplTracerProvider(tp trace.TracerProvider) TracedMilkyWayImplOption {
+	return func(o *tracedMilkyWayImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedMilkyWayImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedMilkyWayImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedMilkyWayImplErrorClassifier(isFailure func(err error) bool) TracedMilkyWayImplOption {
+	return func(o *tracedMilkyWayImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedMilkyWayImpl creates a new trace decorator instance.
+func NewTracedMilkyWayImpl[R constraints.Integer](ctrl generics.MilkyWay[R], opts ...TracedMilkyWayImplOption) *TracedMilkyWayImpl[R] {
+	o := tracedMilkyWayImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedMilkyWayImpl[R]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Water traced base method.
+func (t *TracedMilkyWayImpl[R]) Water(arg0 R) []R {
+	_, span := t.tracer.Start(context.Background(), "MilkyWay.Water")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Water(arg0)
+	return ret
+}
+
+// TracedSolarSystemImpl is a tracing decorator of SolarSystem interface.
+type TracedSolarSystemImpl[T constraints.Ordered] struct {
+	delegate  generics.SolarSystem[T]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedSolarSystemImplOption configures a TracedSolarSystemImpl.
+type TracedSolarSystemImplOption func(*tracedSolarSystemImplOptions)
+
+type tracedSolarSystemImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedSolarSystemImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedSolarSystemImpl. Defaults to the global TracerProvider.
+func WithTracedSolarSystemImplTracerProvider(tp trace.TracerProvider) TracedSolarSystemImplOption {
+	return func(o *tracedSolarSystemImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedSolarSystemImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedSolarSystemImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedSolarSystemImplErrorClassifier(isFailure func(err error) bool) TracedSolarSystemImplOption {
+	return func(o *tracedSolarSystemImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedSolarSystemImpl creates a new trace decorator instance.
+func NewTracedSolarSystemImpl[T constraints.Ordered](ctrl generics.SolarSystem[T], opts ...TracedSolarSystemImplOption) *TracedSolarSystemImpl[T] {
+	o := tracedSolarSystemImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedSolarSystemImpl[T]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Water traced base method.
+func (t *TracedSolarSystemImpl[T]) Water(arg0 T) []T {
+	_, span := t.tracer.Start(context.Background(), "SolarSystem.Water")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Water(arg0)
+	return ret
+}
+
+// TracedEarthImpl is a tracing decorator of Earth interface.
+type TracedEarthImpl[R any] struct {
+	delegate  generics.Earth[R]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedEarthImplOption configures a TracedEarthImpl.
+type TracedEarthImplOption func(*tracedEarthImplOptions)
+
+type tracedEarthImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedEarthImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedEarthImpl. Defaults to the global TracerProvider.
+func WithTracedEarthImplTracerProvider(tp trace.TracerProvider) TracedEarthImplOption {
+	return func(o *tracedEarthImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedEarthImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedEarthImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedEarthImplErrorClassifier(isFailure func(err error) bool) TracedEarthImplOption {
+	return func(o *tracedEarthImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedEarthImpl creates a new trace decorator instance.
+func NewTracedEarthImpl[R any](ctrl generics.Earth[R], opts ...TracedEarthImplOption) *TracedEarthImpl[R] {
+	o := tracedEarthImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedEarthImpl[R]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Water traced base method.
+func (t *TracedEarthImpl[R]) Water(arg0 R) []R {
+	_, span := t.tracer.Start(context.Background(), "Earth.Water")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Water(arg0)
+	return ret
+}
+
+// TracedWaterImpl is a tracing decorator of Water interface.
+type TracedWaterImpl[R any, C generics.UnsignedInteger] struct {
+	delegate  generics.Water[R, C]
+	tracer    trace.Tracer
+	isFailure func(error) bool
+}
+
+// TracedWaterImplOption configures a TracedWaterImpl.
+type TracedWaterImplOption func(*tracedWaterImplOptions)
+
+type tracedWaterImplOptions struct {
+	tracerProvider trace.TracerProvider
+	isFailure      func(err error) bool
+}
+
+// WithTracedWaterImplTracerProvider sets the TracerProvider creating the tracer
+// of a TracedWaterImpl. Defaults to the global TracerProvider.
+func WithTracedWaterImplTracerProvider(tp trace.TracerProvider) TracedWaterImplOption {
+	return func(o *tracedWaterImplOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithTracedWaterImplErrorClassifier sets the function reporting whether an error
+// returned by the delegate of a TracedWaterImpl is a failure, such as
+// to ignore context.Canceled. Defaults to reporting every error as a failure.
+func WithTracedWaterImplErrorClassifier(isFailure func(err error) bool) TracedWaterImplOption {
+	return func(o *tracedWaterImplOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// NewTracedWaterImpl creates a new trace decorator instance.
+func NewTracedWaterImpl[R any, C generics.UnsignedInteger](ctrl generics.Water[R, C], opts ...TracedWaterImplOption) *TracedWaterImpl[R, C] {
+	o := tracedWaterImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	deco := &TracedWaterImpl[R, C]{delegate: ctrl, tracer: o.tracerProvider.Tracer("github.com/pableeee/implgen/mockgen/internal/tests/generics"), isFailure: o.isFailure}
+	return deco
+}
+
+// Fish traced base method.
+func (t *TracedWaterImpl[R, C]) Fish(arg0 R) []C {
+	_, span := t.tracer.Start(context.Background(), "Water.Fish")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("panic: %v", r), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, fmt.Sprint("panic: ", r))
+			panic(r)
+		}
+	}()
+	ret := t.delegate.Fish(arg0)
+	return ret
+}
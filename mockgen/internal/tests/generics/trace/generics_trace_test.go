@@ -0,0 +1,33 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/pableeee/implgen/mockgen/internal/tests/generics"
+)
+
+type earth []int
+
+func (e earth) Water(r int) []int { return append(e, r) }
+
+func TestTracedEarthImpl(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var e generics.Earth[int] = NewTracedEarthImpl[int](earth{1}, WithTracedEarthImplTracerProvider(tp))
+
+	if got, want := e.Water(2), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Water(2) = %v, want %v", got, want)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if got, want := spans[0].Name(), "Earth.Water"; got != want {
+		t.Errorf("span name = %q, want %q", got, want)
+	}
+}
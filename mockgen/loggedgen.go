@@ -0,0 +1,262 @@
+package main
+
+// This file contains the logging decorator, which logs every call with
+// log/slog.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// loggedImports are the packages referenced by the logging decorator.
+var loggedImports = map[string]string{
+	"context":  "",
+	"log/slog": "",
+	"time":     "",
+}
+
+// slogLevels maps the level names to the constants of the slog package.
+var slogLevels = map[string]string{
+	"debug": "LevelDebug",
+	"info":  "LevelInfo",
+	"warn":  "LevelWarn",
+	"error": "LevelError",
+}
+
+// loggingOptions configures the logging decorator.
+type loggingOptions struct {
+	successLevel string // a key of slogLevels; debug if empty
+	failureLevel string // a key of slogLevels; error if empty
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) loggedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Logged" + typeName + "Impl"
+}
+
+// slogAttrs returns the expressions of the slog attributes recording rps.
+// Values of named types are recorded with slog.Any, so that they may
+// implement slog.LogValuer.
+func (g *generator) slogAttrs(rps []recordedParam) []string {
+	var attrs []string
+	for _, rp := range rps {
+		var fn, expr string
+		switch rp.kind {
+		case attrString:
+			fn, expr = "String", rp.expr
+		case attrBool:
+			fn, expr = "Bool", rp.expr
+		case attrInt:
+			fn, expr = "Int", rp.expr
+		case attrInt64:
+			fn, expr = "Int64", "int64("+rp.expr+")"
+		case attrFloat64:
+			fn, expr = "Float64", "float64("+rp.expr+")"
+		case attrDuration:
+			fn, expr = "Duration", rp.expr
+		default:
+			fn, expr = "Any", rp.expr
+		}
+		attrs = append(attrs, fmt.Sprintf("%v(%q, %v)", g.qualify("log/slog", fn), rp.key, expr))
+	}
+	return attrs
+}
+
+func generateLoggedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.loggedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+
+	level := func(name, def string) string {
+		if name == "" {
+			name = def
+		}
+		return g.qualify("log/slog", slogLevels[name])
+	}
+	loggerType := "*" + g.qualify("log/slog", "Logger")
+	contextLoggerType := fmt.Sprintf("func(ctx %v) %v", g.qualify("context", "Context"), loggerType)
+	levelType := g.qualify("log/slog", "Level")
+
+	g.p("")
+	g.p("// %v is a logging decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate      %v", intfType)
+	g.p("log           %v", loggerType)
+	g.p("contextLogger %v", contextLoggerType)
+	g.p("successLevel  %v", levelType)
+	g.p("failureLevel  %v", levelType)
+	g.p("isFailure     func(error) bool")
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "Logger",
+			param: "l",
+			field: "logger",
+			typ:   loggerType,
+			doc: "sets the logger of a " + mockType + ". Defaults to\n" +
+				"the default logger at the time of each call.",
+		},
+		{
+			name:  "ContextLogger",
+			param: "f",
+			field: "contextLogger",
+			typ:   contextLoggerType,
+			doc: "sets the function returning the logger carried\n" +
+				"by the context of a call, if any, to a " + mockType + ". The logger\n" +
+				"is used in place of the one set by With" + mockType + "Logger.",
+		},
+		{
+			name:  "SuccessLevel",
+			param: "level",
+			field: "successLevel",
+			typ:   levelType,
+			def:   level(g.logging.successLevel, "debug"),
+			doc:   "sets the level of the calls that succeed.",
+		},
+		{
+			name:  "FailureLevel",
+			param: "level",
+			field: "failureLevel",
+			typ:   levelType,
+			def:   level(g.logging.failureLevel, "error"),
+			doc:   "sets the level of the calls that fail.",
+		},
+		errorClassifierOption(mockType),
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new logging decorator instance.", mockType)
+	g.p("func New%v%v(ctrl %v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate:      ctrl,")
+	g.p("log:           o.logger,")
+	g.p("contextLogger: o.contextLogger,")
+	g.p("successLevel:  o.successLevel,")
+	g.p("failureLevel:  o.failureLevel,")
+	g.p("isFailure:     o.isFailure,")
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+	g.p("")
+
+	g.p("// logger returns the logger of a call with the given context.")
+	g.p("func (t *%v%v) logger(ctx %v) %v {", mockType, shortTp, g.qualify("context", "Context"), loggerType)
+	g.in()
+	g.p("if t.contextLogger != nil {")
+	g.in()
+	g.p("if l := t.contextLogger(ctx); l != nil {")
+	g.in()
+	g.p("return l")
+	g.out()
+	g.p("}")
+	g.out()
+	g.p("}")
+	g.p("if t.log != nil {")
+	g.in()
+	g.p("return t.log")
+	g.out()
+	g.p("}")
+	g.p("return %v()", g.qualify("log/slog", "Default"))
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateLoggedMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateLoggedMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+	idBegin := ia.allocateIdentifier("begin")
+	idRecovered := ia.allocateIdentifier("r")
+	idLevel := ia.allocateIdentifier("level")
+	idLogger := ia.allocateIdentifier("l")
+
+	ctx := g.qualify("context", "Background") + "()"
+	if i := contextArgIndex(m); i >= 0 {
+		ctx = argNames[i]
+	}
+	msg := intf.Name + "." + m.Name
+	attrs := []string{
+		fmt.Sprintf("%v(%q, %q)", g.qualify("log/slog", "String"), "method", m.Name),
+		fmt.Sprintf("%v(%q, %v(%v))", g.qualify("log/slog", "Duration"), "duration", g.qualify("time", "Since"), idBegin),
+	}
+	attrs = append(attrs, g.slogAttrs(g.attributes.recordedArgs(intf.Name, m, argNames))...)
+
+	g.p("// %v logged base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	g.p("%v := %v()", idBegin, g.qualify("time", "Now"))
+	g.p("defer func() {")
+	g.in()
+	g.p("if %v := recover(); %v != nil {", idRecovered, idRecovered)
+	g.in()
+	panicAttrs := append(attrs[:len(attrs):len(attrs)], fmt.Sprintf("%v(%q, %v)", g.qualify("log/slog", "Any"), "panic", idRecovered))
+	g.p("%v.logger(%v).LogAttrs(%v, %v.failureLevel, %q, %v)", idRecv, ctx, ctx, idRecv, msg, strings.Join(panicAttrs, ", "))
+	g.p("panic(%v)", idRecovered)
+	g.out()
+	g.p("}")
+	g.out()
+	g.p("}()")
+
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+	callArgs := makeCallArgs(m, argNames)
+	if len(returns) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+	}
+
+	g.p("%v := %v.successLevel", idLevel, idRecv)
+	if er := errorResultOf(m); er.index >= 0 {
+		cond, err := er.errorCheck(returns, ia)
+		g.p("if %v && %v.isFailure(%v) {", cond, idRecv, err)
+		g.in()
+		g.p("%v = %v.failureLevel", idLevel, idRecv)
+		g.out()
+		g.p("}")
+		attrs = append(attrs, fmt.Sprintf("%v(%q, %v)", g.qualify("log/slog", "Any"), "error", returns[er.index]))
+	}
+	attrs = append(attrs, g.slogAttrs(g.attributes.recordedResults(intf.Name, m, returns))...)
+	g.p("if %v := %v.logger(%v); %v.Enabled(%v, %v) {", idLogger, idRecv, ctx, idLogger, ctx, idLevel)
+	g.in()
+	g.p("%v.LogAttrs(%v, %v, %q, %v)", idLogger, ctx, idLevel, msg, strings.Join(attrs, ", "))
+	g.out()
+	g.p("}")
+
+	if len(returns) > 0 {
+		g.p("return %v", strings.Join(returns, ", "))
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateLoggedInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", "log/slog": "slog", "time": "time"},
+		logging:    loggingOptions{successLevel: "info"},
+		attributes: attributeOptions{
+			args:    parseSelectors("Store.Get"),
+			exclude: parseSelectors("token"),
+		},
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("string")},
+			{Name: "token", Type: model.PredeclaredType("string")},
+			{Name: "wait", Type: &model.NamedType{Package: "time", Type: "Duration"}},
+		},
+		Out: []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateLoggedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		`func NewLoggedStoreImpl(ctrl Store, opts ...LoggedStoreImplOption) *LoggedStoreImpl {`,
+		`o := loggedStoreImplOptions{successLevel: slog.LevelInfo, failureLevel: slog.LevelError, isFailure: func(error) bool { return true }}`,
+		`if ret != nil && t.isFailure(ret) {`,
+		`if l := t.logger(ctx); l.Enabled(ctx, level) {`,
+		`l.LogAttrs(ctx, level, "Store.Get", slog.String("method", "Get"), slog.Duration("duration", time.Since(begin)), slog.String("key", key), slog.Duration("wait", wait), slog.Any("error", ret))`,
+		`if l := t.logger(context.Background()); l.Enabled(context.Background(), level) {`,
+		`l.LogAttrs(context.Background(), level, "Store.Close", slog.String("method", "Close"), slog.Duration("duration", time.Since(begin)))`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"token"`) {
+		t.Errorf("generated code logs an excluded argument:\n%s", out)
+	}
+}
@@ -0,0 +1,428 @@
+package main
+
+// This file contains the backends the metrics decorator can record to.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	goKitMetricsImportPath    = "github.com/go-kit/kit/metrics"
+	goKitPrometheusImportPath = "github.com/go-kit/kit/metrics/prometheus"
+	prometheusImportPath      = "github.com/prometheus/client_golang/prometheus"
+	otelImportPath            = "go.opentelemetry.io/otel"
+	otelAttributeImportPath   = "go.opentelemetry.io/otel/attribute"
+	otelMetricImportPath      = "go.opentelemetry.io/otel/metric"
+)
+
+// metricsBackend writes the parts of the metrics decorator that depend on
+// the library the metrics are recorded with.
+type metricsBackend interface {
+	// imports returns the packages the backend refers to, mapped to their
+	// preferred package names.
+	imports() map[string]string
+	// fields writes the fields of the decorator holding the instruments.
+	fields(g *generator)
+	// init writes the constructor statements that create the instruments
+	// and assign them to the fields of deco.
+	init(g *generator, deco string, n *metricsNames) error
+	// begin writes the statements run before calling the delegate.
+	begin(g *generator, c *metricsCall, ia identifierAllocator)
+	// countError writes the statements run when the delegate failed.
+	countError(g *generator, c *metricsCall)
+	// countPanic writes the statements run when the delegate panicked,
+	// besides countError and observe.
+	countPanic(g *generator, c *metricsCall)
+	// observe writes the statements recording the duration of the call.
+	observe(g *generator, c *metricsCall)
+	// labelsErrors reports whether observe refers to c.failed and
+	// c.panicked.
+	labelsErrors() bool
+}
+
+var metricsBackends = map[string]metricsBackend{
+	"prometheus": prometheusMetrics{},
+	"otel":       otelMetrics{},
+	"expvar":     expvarMetrics{},
+}
+
+// metricsNames are the names, help texts and unit of the metrics recorded
+// for an interface, with the defaults applied.
+type metricsNames struct {
+	metricsOptions
+	scope        string // instrumentation scope, for backends that have one
+	durationHelp string
+	callsHelp    string
+	errorsHelp   string
+	inFlightHelp string
+	conv         metricsUnit
+}
+
+// fqName joins the non-empty namespace, subsystem and name with underscores,
+// the way Prometheus builds fully-qualified metric names.
+func (n *metricsNames) fqName(name string) string {
+	var parts []string
+	for _, p := range []string{n.namespace, n.subsystem, name} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// metricsCall describes a decorated method call while its recording
+// statements are written.
+type metricsCall struct {
+	recv     string // identifier of the decorator
+	method   string // name of the method
+	ctx      string // expression of the context of the call
+	failed   string // expression of the "error" label value
+	panicked string // expression of the "panic" label value
+	took     string // expression of the duration of the call, in the unit
+	local    string // identifier declared by begin, if any
+	names    *metricsNames
+}
+
+// metricsUnit describes how a time.Duration is converted to a unit.
+type metricsUnit struct {
+	ucum    string  // UCUM symbol of the unit
+	observe string  // format converting the duration operand to a float64
+	scale   float64 // number of units in a second
+}
+
+var metricsUnits = map[string]metricsUnit{
+	"seconds":      {ucum: "s", observe: "%v.Seconds()", scale: 1},
+	"milliseconds": {ucum: "ms", observe: "float64(%v) / float64(time.Millisecond)", scale: 1e3},
+	"microseconds": {ucum: "us", observe: "float64(%v) / float64(time.Microsecond)", scale: 1e6},
+}
+
+// defaultBuckets mirrors the Prometheus client's DefBuckets, in seconds.
+var defaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// parseMetricsBuckets splits spec, which is one of "default", a
+// comma-separated list of upper bounds, "exponential:start,factor,count" or
+// "linear:start,width,count", into its layout and arguments.
+func parseMetricsBuckets(spec string) (string, []string, error) {
+	if spec == "" || spec == "default" {
+		return "default", nil, nil
+	}
+
+	kind, args, ok := strings.Cut(spec, ":")
+	if !ok {
+		args, kind = spec, ""
+	}
+	var values []string
+	var nums []float64
+	for _, v := range strings.Split(args, ",") {
+		v = strings.TrimSpace(v)
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: %v", spec, err)
+		}
+		values = append(values, v)
+		nums = append(nums, n)
+	}
+
+	// The layouts that the Prometheus client rejects are rejected here, as
+	// it panics on them.
+	switch kind {
+	case "":
+		for i := 1; i < len(nums); i++ {
+			if nums[i] <= nums[i-1] {
+				return "", nil, fmt.Errorf("bad metrics buckets %q: bound %v is not greater than %v", spec, values[i], values[i-1])
+			}
+		}
+		return kind, values, nil
+	case "exponential", "linear":
+		if len(values) != 3 {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: want %v:start,step,count", spec, kind)
+		}
+		if count, err := strconv.Atoi(values[2]); err != nil || count < 1 {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: count %v must be a positive integer", spec, values[2])
+		}
+		if kind == "exponential" && nums[0] <= 0 {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: start %v must be positive", spec, values[0])
+		}
+		if kind == "exponential" && nums[1] <= 1 {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: factor %v must be greater than 1", spec, values[1])
+		}
+		if kind == "linear" && nums[1] <= 0 {
+			return "", nil, fmt.Errorf("bad metrics buckets %q: width %v must be positive", spec, values[1])
+		}
+		return kind, values, nil
+	}
+	return "", nil, fmt.Errorf("bad metrics buckets %q: unknown layout %q", spec, kind)
+}
+
+// metricsBuckets returns the Go expression of the Prometheus histogram
+// buckets described by spec. The default buckets are scaled to the unit.
+func (g *generator) metricsBuckets(spec string, scale float64) (string, error) {
+	kind, values, err := parseMetricsBuckets(spec)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case "default":
+		if scale == 1 {
+			return g.qualify(prometheusImportPath, "DefBuckets"), nil
+		}
+		bounds, _ := metricsBucketBounds(spec, scale)
+		return "[]float64{" + formatFloats(bounds) + "}", nil
+	case "exponential":
+		return fmt.Sprintf("%v(%v)", g.qualify(prometheusImportPath, "ExponentialBuckets"), strings.Join(values, ", ")), nil
+	case "linear":
+		return fmt.Sprintf("%v(%v)", g.qualify(prometheusImportPath, "LinearBuckets"), strings.Join(values, ", ")), nil
+	}
+	return "[]float64{" + strings.Join(values, ", ") + "}", nil
+}
+
+// metricsBucketBounds returns the upper bounds of the histogram buckets
+// described by spec. The default buckets are scaled to the unit.
+func metricsBucketBounds(spec string, scale float64) ([]float64, error) {
+	kind, values, err := parseMetricsBuckets(spec)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "default" {
+		bounds := make([]float64, len(defaultBuckets))
+		for i, b := range defaultBuckets {
+			bounds[i] = b * scale
+		}
+		return bounds, nil
+	}
+
+	nums := make([]float64, len(values))
+	for i, v := range values {
+		nums[i], _ = strconv.ParseFloat(v, 64)
+	}
+	if kind == "" {
+		return nums, nil
+	}
+	bounds := make([]float64, int(nums[2]))
+	for i := range bounds {
+		if kind == "exponential" {
+			bounds[i] = nums[0] * math.Pow(nums[1], float64(i))
+		} else {
+			bounds[i] = nums[0] + nums[1]*float64(i)
+		}
+	}
+	return bounds, nil
+}
+
+func formatFloats(fs []float64) string {
+	s := make([]string, len(fs))
+	for i, f := range fs {
+		s[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strings.Join(s, ", ")
+}
+
+// prometheusMetrics records go-kit metrics backed by the Prometheus client.
+type prometheusMetrics struct{}
+
+func (prometheusMetrics) imports() map[string]string {
+	return map[string]string{
+		"time":                    "",
+		goKitMetricsImportPath:    "",
+		goKitPrometheusImportPath: "",
+		prometheusImportPath:      "stdprometheus",
+	}
+}
+
+func (prometheusMetrics) fields(g *generator) {
+	g.p("duration %v", g.qualify(goKitMetricsImportPath, "Histogram"))
+	g.p("calls    %v", g.qualify(goKitMetricsImportPath, "Counter"))
+	g.p("errors   %v", g.qualify(goKitMetricsImportPath, "Counter"))
+	g.p("inFlight %v", g.qualify(goKitMetricsImportPath, "Gauge"))
+}
+
+func (prometheusMetrics) init(g *generator, deco string, n *metricsNames) error {
+	buckets, err := g.metricsBuckets(n.buckets, n.conv.scale)
+	if err != nil {
+		return err
+	}
+
+	opts := func(name, help string) {
+		if n.namespace != "" {
+			g.p(`Namespace: %q,`, n.namespace)
+		}
+		g.p(`Subsystem: %q,`, n.subsystem)
+		g.p(`Name: %q,`, name)
+		g.p(`Help: %q,`, help)
+	}
+
+	g.p(`%v.duration = %v(%v{`, deco, g.qualify(goKitPrometheusImportPath, "NewHistogramFrom"), g.qualify(prometheusImportPath, "HistogramOpts"))
+	g.in()
+	opts(n.name, n.durationHelp)
+	g.p(`Buckets: %v,`, buckets)
+	g.out()
+	g.p(`}, []string{"method", "error", "panic"})`)
+
+	g.p(`%v.calls = %v(%v{`, deco, g.qualify(goKitPrometheusImportPath, "NewCounterFrom"), g.qualify(prometheusImportPath, "CounterOpts"))
+	g.in()
+	opts("calls_total", n.callsHelp)
+	g.out()
+	g.p(`}, []string{"method"})`)
+
+	g.p(`%v.errors = %v(%v{`, deco, g.qualify(goKitPrometheusImportPath, "NewCounterFrom"), g.qualify(prometheusImportPath, "CounterOpts"))
+	g.in()
+	opts("errors_total", n.errorsHelp)
+	g.out()
+	g.p(`}, []string{"method"})`)
+
+	g.p(`%v.inFlight = %v(%v{`, deco, g.qualify(goKitPrometheusImportPath, "NewGaugeFrom"), g.qualify(prometheusImportPath, "GaugeOpts"))
+	g.in()
+	opts("in_flight", n.inFlightHelp)
+	g.out()
+	g.p(`}, []string{"method"})`)
+	return nil
+}
+
+func (prometheusMetrics) begin(g *generator, c *metricsCall, ia identifierAllocator) {
+	c.local = ia.allocateIdentifier("inFlight")
+	g.p(`%v.calls.With("method", %q).Add(1)`, c.recv, c.method)
+	g.p(`%v := %v.inFlight.With("method", %q)`, c.local, c.recv, c.method)
+	g.p("%v.Add(1)", c.local)
+	g.p("defer %v.Add(-1)", c.local)
+}
+
+func (prometheusMetrics) countError(g *generator, c *metricsCall) {
+	g.p(`%v.errors.With("method", %q).Add(1)`, c.recv, c.method)
+}
+
+// countPanic records nothing, as the duration of the call is labelled.
+func (prometheusMetrics) countPanic(*generator, *metricsCall) {}
+
+func (prometheusMetrics) observe(g *generator, c *metricsCall) {
+	g.p(`%v.duration.With("method", %q, "error", %v, "panic", %v).Observe(%v)`, c.recv, c.method, c.failed, c.panicked, c.took)
+}
+
+func (prometheusMetrics) labelsErrors() bool { return true }
+
+// otelMetrics records OpenTelemetry metrics with the global meter provider.
+type otelMetrics struct{}
+
+func (otelMetrics) imports() map[string]string {
+	return map[string]string{
+		"context":               "",
+		"time":                  "",
+		otelImportPath:          "",
+		otelAttributeImportPath: "",
+		otelMetricImportPath:    "",
+	}
+}
+
+func (otelMetrics) fields(g *generator) {
+	g.p("duration %v", g.qualify(otelMetricImportPath, "Float64Histogram"))
+	g.p("calls    %v", g.qualify(otelMetricImportPath, "Int64Counter"))
+	g.p("errors   %v", g.qualify(otelMetricImportPath, "Int64Counter"))
+	g.p("inFlight %v", g.qualify(otelMetricImportPath, "Int64UpDownCounter"))
+}
+
+func (otelMetrics) init(g *generator, deco string, n *metricsNames) error {
+	bounds, err := metricsBucketBounds(n.buckets, n.conv.scale)
+	if err != nil {
+		return err
+	}
+
+	// Instrument creation only fails on invalid names or options, in which
+	// case the meter still returns a usable no-op instrument.
+	instrument := func(field, kind, name, help string, opts ...string) {
+		g.p("if %v.%v, err = meter.%v(%q, %v(%q)%v); err != nil {", deco, field, kind, name,
+			g.qualify(otelMetricImportPath, "WithDescription"), help, strings.Join(append([]string{""}, opts...), ", "))
+		g.in()
+		g.p("%v(err)", g.qualify(otelImportPath, "Handle"))
+		g.out()
+		g.p("}")
+	}
+
+	g.p("meter := %v(%q)", g.qualify(otelImportPath, "Meter"), n.scope)
+	g.p("var err error")
+	instrument("duration", "Float64Histogram", n.fqName(n.name), n.durationHelp,
+		fmt.Sprintf("%v(%q)", g.qualify(otelMetricImportPath, "WithUnit"), n.conv.ucum),
+		fmt.Sprintf("%v(%v)", g.qualify(otelMetricImportPath, "WithExplicitBucketBoundaries"), formatFloats(bounds)))
+	instrument("calls", "Int64Counter", n.fqName("calls_total"), n.callsHelp)
+	instrument("errors", "Int64Counter", n.fqName("errors_total"), n.errorsHelp)
+	instrument("inFlight", "Int64UpDownCounter", n.fqName("in_flight"), n.inFlightHelp)
+	return nil
+}
+
+func (otelMetrics) begin(g *generator, c *metricsCall, ia identifierAllocator) {
+	c.local = ia.allocateIdentifier("attrs")
+	g.p(`%v := %v(%v(%q, %q))`, c.local, g.qualify(otelMetricImportPath, "WithAttributes"), g.qualify(otelAttributeImportPath, "String"), "method", c.method)
+	g.p("%v.calls.Add(%v, 1, %v)", c.recv, c.ctx, c.local)
+	g.p("%v.inFlight.Add(%v, 1, %v)", c.recv, c.ctx, c.local)
+	g.p("defer %v.inFlight.Add(%v, -1, %v)", c.recv, c.ctx, c.local)
+}
+
+func (otelMetrics) countError(g *generator, c *metricsCall) {
+	g.p("%v.errors.Add(%v, 1, %v)", c.recv, c.ctx, c.local)
+}
+
+// countPanic records nothing, as the duration of the call is labelled.
+func (otelMetrics) countPanic(*generator, *metricsCall) {}
+
+func (otelMetrics) observe(g *generator, c *metricsCall) {
+	attribute := func(key, value string) string {
+		return fmt.Sprintf("%v(%q, %v)", g.qualify(otelAttributeImportPath, "String"), key, value)
+	}
+	g.p("%v.duration.Record(%v, %v, %v(%v, %v, %v))", c.recv, c.ctx, c.took, g.qualify(otelMetricImportPath, "WithAttributes"),
+		attribute("method", strconv.Quote(c.method)), attribute("error", c.failed), attribute("panic", c.panicked))
+}
+
+func (otelMetrics) labelsErrors() bool { return true }
+
+// expvarMetrics publishes the metrics with the standard library's expvar
+// package, as a map keyed by method and metric name. Histograms are not
+// available, so it records the total duration of the calls instead.
+type expvarMetrics struct{}
+
+func (expvarMetrics) imports() map[string]string {
+	return map[string]string{
+		"expvar": "",
+		"time":   "",
+	}
+}
+
+func (expvarMetrics) fields(g *generator) {
+	g.p("vars *%v", g.qualify("expvar", "Map"))
+}
+
+func (expvarMetrics) init(g *generator, deco string, n *metricsNames) error {
+	if _, err := metricsBucketBounds(n.buckets, n.conv.scale); err != nil {
+		return err
+	}
+
+	// Reuse the published map, as expvar panics when a name is published twice.
+	name := n.fqName("")
+	g.p("%v.vars, _ = %v(%q).(*%v)", deco, g.qualify("expvar", "Get"), name, g.qualify("expvar", "Map"))
+	g.p("if %v.vars == nil {", deco)
+	g.in()
+	g.p("%v.vars = %v(%q)", deco, g.qualify("expvar", "NewMap"), name)
+	g.out()
+	g.p("}")
+	return nil
+}
+
+func (expvarMetrics) begin(g *generator, c *metricsCall, ia identifierAllocator) {
+	g.p(`%v.vars.Add("%v.calls_total", 1)`, c.recv, c.method)
+	g.p(`%v.vars.Add("%v.in_flight", 1)`, c.recv, c.method)
+	g.p(`defer %v.vars.Add("%v.in_flight", -1)`, c.recv, c.method)
+}
+
+func (expvarMetrics) countError(g *generator, c *metricsCall) {
+	g.p(`%v.vars.Add("%v.errors_total", 1)`, c.recv, c.method)
+}
+
+func (expvarMetrics) countPanic(g *generator, c *metricsCall) {
+	g.p(`%v.vars.Add("%v.panics_total", 1)`, c.recv, c.method)
+}
+
+func (expvarMetrics) observe(g *generator, c *metricsCall) {
+	g.p(`%v.vars.AddFloat("%v.%v", %v)`, c.recv, c.method, c.names.name, c.took)
+}
+
+func (expvarMetrics) labelsErrors() bool { return false }
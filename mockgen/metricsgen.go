@@ -26,6 +26,55 @@ import (
 	"github.com/pableeee/implgen/mockgen/model"
 )
 
+// metricsOptions configures the names, help text, unit and buckets of the
+// metrics recorded by the metrics decorator.
+type metricsOptions struct {
+	backend   metricsBackend // defaults to prometheusMetrics
+	namespace string
+	subsystem string // defaults to the interface name
+	name      string // name of the duration histogram, without the unit suffix
+	help      string // help text of the duration histogram; may be empty
+	unit      string // one of the keys of metricsUnits
+	buckets   string // see parseMetricsBuckets
+}
+
+// metricsNames applies the defaults to the options for the given interface.
+func (g *generator) metricsNames(intf *model.Interface) (*metricsNames, error) {
+	n := &metricsNames{metricsOptions: g.metrics}
+	if n.backend == nil {
+		n.backend = prometheusMetrics{}
+	}
+	if n.subsystem == "" {
+		n.subsystem = intf.Name
+	}
+	if n.name == "" {
+		n.name = "duration"
+	}
+	if n.unit == "" {
+		n.unit = "seconds"
+	}
+	conv, ok := metricsUnits[n.unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown metrics unit %q", n.unit)
+	}
+	n.conv = conv
+	if !strings.HasSuffix(n.name, "_"+n.unit) {
+		n.name += "_" + n.unit
+	}
+	if n.help == "" {
+		n.help = fmt.Sprintf("Time spent in %v calls, in %v.", intf.Name, n.unit)
+	}
+	n.scope = g.pkgPath
+	if n.scope == "" {
+		n.scope = intf.Name
+	}
+	n.durationHelp = n.help
+	n.callsHelp = fmt.Sprintf("Total number of %v calls.", intf.Name)
+	n.errorsHelp = fmt.Sprintf("Total number of %v calls that returned an error.", intf.Name)
+	n.inFlightHelp = fmt.Sprintf("Number of %v calls currently in flight.", intf.Name)
+	return n, nil
+}
+
 // The name of the mock type to use for the given interface identifier.
 func (g *generator) metricsName(typeName string) string {
 	if mockName, ok := g.mockNames[typeName]; ok {
@@ -38,85 +87,58 @@ func (g *generator) metricsName(typeName string) string {
 func generateMetricsInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
 	mockType := g.metricsName(intf.Name)
 	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
 
-	g.p("")
-	g.p("// %v is a tracing decorator of %v interface.", mockType, intf.Name)
-	g.p("type Metrics%v interface {", intf.Name)
-	g.in()
-
-	for _, m := range intf.Methods {
-		argNames := g.getArgNames(m, true)
-		argTypes := g.getArgTypes(m, outputPackagePath, true)
-		argString := makeArgString(argNames, argTypes)
-
-		rets := make([]string, len(m.Out))
-		for i, p := range m.Out {
-			rets[i] = p.Type.String(g.packageMap, outputPackagePath)
-		}
-		retString := strings.Join(rets, ", ")
-		if len(rets) > 1 {
-			retString = "(" + retString + ")"
-		}
-		if retString != "" {
-			retString = " " + retString
-		}
-
-		g.p("%s(%s) %s", m.Name, argString, retString)
+	names, err := g.metricsNames(intf)
+	if err != nil {
+		return err
 	}
 
-	g.out()
-	g.p("}")
-	g.p("")
-
 	g.p("")
 	g.p("// %v is a metrics decorator of %v interface.", mockType, intf.Name)
 	g.p("type %v%v struct {", mockType, longTp)
 	g.in()
-	g.p("duration metrics.Histogram")
-	g.p("delegate Metrics%v", intf.Name)
+	names.backend.fields(g)
+	g.p("delegate  %v", intfType)
+	g.p("isFailure func(error) bool")
 	g.out()
 	g.p("}")
 	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
 
-	g.p("// New%v creates a new metrics decorator instance.", mockType)
-	g.p("func New%v%v(ctrl Metrics%v) *%v%v {", mockType, longTp, intf.Name, mockType, shortTp)
-	g.in()
-
-	g.p(`histogram := prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{`)
-	g.in()
-	g.p(`Subsystem: "%v",`, intf.Name)
-	g.p(`Name: "duration_milliseconds",`)
-	g.p(`Help: "Seconds spent quering the rate limiter",`)
-	g.p(`Buckets: stdprometheus.DefBuckets,`)
-	g.out()
-	g.p(`}, []string{"error"})`)
+	opts := []decoratorOption{errorClassifierOption(mockType)}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
 
-	g.p(`deco := &%v%v{`, mockType, shortTp)
+	g.p("// New%v creates a new metrics decorator instance.", mockType)
+	g.p("func New%v%v(ctrl %v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
 	g.in()
-	g.p(`delegate: ctrl,`)
-	g.p(`duration: histogram,`)
-	g.out()
-	g.p(`}`)
+	g.generateApplyOptions(mockType, opts)
+	g.p(`deco := &%v%v{delegate: ctrl, isFailure: o.isFailure}`, mockType, shortTp)
+	if err := names.backend.init(g, "deco", names); err != nil {
+		return err
+	}
 	g.p("return deco")
 	g.out()
 	g.p("}")
 	g.p("")
 
-	generateMetricsMethods(g, mockType, intf, outputPackagePath, shortTp)
+	generateMetricsMethods(g, mockType, intf, outputPackagePath, shortTp, names)
 
 	return nil
 }
-func generateMetricsMethods(g *generator, mockType string, intf *model.Interface, pkgOverride, shortTp string) {
+
+func generateMetricsMethods(g *generator, mockType string, intf *model.Interface, pkgOverride, shortTp string, names *metricsNames) {
 	sort.Sort(byMethodName(intf.Methods))
 	for _, m := range intf.Methods {
 		g.p("")
-		_ = generateMetricsMethod(g, mockType, m, pkgOverride, shortTp)
+		_ = generateMetricsMethod(g, mockType, m, pkgOverride, shortTp, names)
 	}
 }
 
 // GenerateMockMethod generates a mock method implementation.
 // If non-empty, pkgOverride is the package in which unqualified types reside.
-func generateMetricsMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) error {
+func generateMetricsMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string, names *metricsNames) error {
 	argNames := g.getArgNames(m, true)
 	argTypes := g.getArgTypes(m, pkgOverride, true)
 	argString := makeArgString(argNames, argTypes)
@@ -139,9 +161,24 @@ func generateMetricsMethod(g *generator, mockType string, m *model.Method, pkgOv
 	idTook := ia.allocateIdentifier("took")
 	idFailed := ia.allocateIdentifier("failed")
 
+	c := &metricsCall{
+		recv:     idRecv,
+		method:   m.Name,
+		ctx:      g.qualify("context", "Background") + "()",
+		failed:   idFailed,
+		panicked: `"false"`,
+		took:     fmt.Sprintf(names.conv.observe, idTook),
+		names:    names,
+	}
+	if i := contextArgIndex(m); i >= 0 {
+		c.ctx = argNames[i]
+	}
+	labelsErrors := names.backend.labelsErrors()
+
 	g.p("// %v Metrics base method.", m.Name)
 	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
 	g.in()
+	names.backend.begin(g, c, ia)
 	g.p("%v := time.Now()", idBegin)
 
 	var callArgs string
@@ -161,37 +198,56 @@ func generateMetricsMethod(g *generator, mockType string, m *model.Method, pkgOv
 
 	}
 
-	g.p(`%v := "N/A"`, idFailed)
+	// Record a panic of the delegate as a failure before propagating it.
+	idRecovered := ia.allocateIdentifier("r")
+	panicked := *c
+	panicked.failed, panicked.panicked = `"true"`, `"true"`
+	g.p("defer func() {")
+	g.in()
+	g.p("if %v := recover(); %v != nil {", idRecovered, idRecovered)
+	g.in()
+	g.p("%v := time.Since(%v)", idTook, idBegin)
+	names.backend.countError(g, &panicked)
+	names.backend.countPanic(g, &panicked)
+	names.backend.observe(g, &panicked)
+	g.p("panic(%v)", idRecovered)
+	g.out()
+	g.p("}")
+	g.out()
+	g.p("}()")
+
+	if labelsErrors {
+		g.p(`%v := "N/A"`, idFailed)
+	}
 	if len(m.Out) == 0 {
 		g.p(`%s.delegate.%s(%s)`, idRecv, m.Name, callArgs)
 		g.p("%v := time.Since(%v)", idTook, idBegin)
-		g.p(`%v.duration.With("error",%v).Observe(%v.Seconds())`, idRecv, idFailed, idTook)
+		names.backend.observe(g, c)
 	} else {
-		returnsError := false
-		errorIndex := -1
 		returns := make([]string, len(rets))
-		for i, r := range rets {
+		for i := range rets {
 			returns[i] = ia.allocateIdentifier("ret")
-			if r == "error" {
-				returnsError = true
-				errorIndex = i
-			}
-
 		}
 
 		returnArgsString := strings.Join(returns, ", ")
 		g.p(`%s := %s.delegate.%s(%s)`, returnArgsString, idRecv, m.Name, callArgs)
 		g.p("%v := time.Since(%v)", idTook, idBegin)
 
-		if returnsError {
-			g.p(`%v = "false"`, idFailed)
-			g.p(`if %v != nil {`, returns[errorIndex])
+		if er := errorResultOf(m); er.index >= 0 {
+			if labelsErrors {
+				g.p(`%v = "false"`, idFailed)
+			}
+			cond, err := er.errorCheck(returns, ia)
+			g.p(`if %v && %v.isFailure(%v) {`, cond, idRecv, err)
 			g.in()
-			g.p(`%v = "true"`, idFailed)
+			if labelsErrors {
+				g.p(`%v = "true"`, idFailed)
+			}
+			names.backend.countError(g, c)
 			g.out()
 			g.p("}")
 		}
-		g.p(`%v.duration.With("error",%v).Observe(%v.Seconds())`, idRecv, idFailed, idTook)
+		names.backend.observe(g, c)
 		g.p(`return %s`, returnArgsString)
 
 	}
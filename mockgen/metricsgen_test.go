@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateMetricsInterface_MethodLabels(t *testing.T) {
+	g := generator{}
+	intf := &model.Interface{Name: "Somename"}
+	intf.AddMethod(&model.Method{
+		Name: "MethodA",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "MethodB"})
+
+	if err := generateMetricsInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		`t.calls.With("method", "MethodA").Add(1)`,
+		`inFlight := t.inFlight.With("method", "MethodA")`,
+		`t.errors.With("method", "MethodA").Add(1)`,
+		`t.duration.With("method", "MethodA", "error", failed, "panic", "false")`,
+		`t.calls.With("method", "MethodB").Add(1)`,
+		`t.duration.With("method", "MethodB", "error", failed, "panic", "false")`,
+		`t.duration.With("method", "MethodB", "error", "true", "panic", "true")`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	// Methods that cannot fail only count panics as errors.
+	if n := strings.Count(out, `t.errors.With("method", "MethodB")`); n != 1 {
+		t.Errorf("generated code counts errors of a method that cannot fail %d times, want 1:\n%s", n, out)
+	}
+}
+
+func TestGenerateMetricsInterface_Naming(t *testing.T) {
+	g := generator{metrics: metricsOptions{
+		namespace: "shop",
+		name:      "latency",
+		help:      "Latency of the store.",
+		unit:      "milliseconds",
+	}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{Name: "Get"})
+
+	if err := generateMetricsInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		`Namespace: "shop",`,
+		`Subsystem: "Store",`,
+		`Name: "latency_milliseconds",`,
+		`Help: "Latency of the store.",`,
+		`Buckets: []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},`,
+		`Observe(float64(took) / float64(time.Millisecond))`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMetricsBuckets(t *testing.T) {
+	g := generator{packageMap: map[string]string{prometheusImportPath: "stdprometheus"}}
+	for _, tc := range []struct {
+		spec    string
+		scale   float64
+		want    string
+		wantErr bool
+	}{
+		{spec: "default", scale: 1, want: "stdprometheus.DefBuckets"},
+		{spec: "", scale: 1e6, want: "[]float64{5000, 10000, 25000, 50000, 100000, 250000, 500000, 1e+06, 2.5e+06, 5e+06, 1e+07}"},
+		{spec: "0.1, 0.5,1", scale: 1, want: "[]float64{0.1, 0.5, 1}"},
+		{spec: "exponential:0.001,2,10", scale: 1, want: "stdprometheus.ExponentialBuckets(0.001, 2, 10)"},
+		{spec: "linear:10,10,5", scale: 1e3, want: "stdprometheus.LinearBuckets(10, 10, 5)"},
+		{spec: "linear:10,10", wantErr: true},
+		{spec: "exponential:1,2,3.5", wantErr: true},
+		{spec: "fibonacci:1,2,3", wantErr: true},
+		{spec: "1,two", wantErr: true},
+		{spec: "exponential:1,2,-3", wantErr: true},
+		{spec: "exponential:1,2,0", wantErr: true},
+		{spec: "exponential:1,1,3", wantErr: true},
+		{spec: "exponential:0,2,3", wantErr: true},
+		{spec: "linear:1,0,3", wantErr: true},
+		{spec: "linear:1,-1,3", wantErr: true},
+		{spec: "0.5,0.1,1", wantErr: true},
+		{spec: "0.1,0.1,1", wantErr: true},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			got, err := g.metricsBuckets(tc.spec, tc.scale)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("metricsBuckets(%q) = %q, want error", tc.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("metricsBuckets(%q) = %q, want %q", tc.spec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMetricsBucketBounds(t *testing.T) {
+	for _, tc := range []struct {
+		spec  string
+		scale float64
+		want  string
+	}{
+		{spec: "default", scale: 1e3, want: "5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000"},
+		{spec: "1,2,3", scale: 1e3, want: "1, 2, 3"},
+		{spec: "exponential:1,2,4", scale: 1, want: "1, 2, 4, 8"},
+		{spec: "linear:10,5,3", scale: 1, want: "10, 15, 20"},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			bounds, err := metricsBucketBounds(tc.spec, tc.scale)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := formatFloats(bounds); got != tc.want {
+				t.Errorf("metricsBucketBounds(%q) = %v, want %v", tc.spec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGenerateMetricsInterface_Panic(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{otelAttributeImportPath: "attribute", otelMetricImportPath: "metric"},
+		metrics:    metricsOptions{backend: otelMetrics{}},
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateMetricsInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		`if r := recover(); r != nil {`,
+		`metric.WithAttributes(attribute.String("method", "Close"), attribute.String("error", "true"), attribute.String("panic", "true"))`,
+		`metric.WithAttributes(attribute.String("method", "Close"), attribute.String("error", failed), attribute.String("panic", "false"))`,
+		`panic(r)`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
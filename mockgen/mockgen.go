@@ -35,7 +35,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/mod/modfile"
 	toolsimports "golang.org/x/tools/imports"
@@ -68,7 +70,34 @@ var (
 	imports                = flag.String("imports", "", "(source mode) Comma-separated name=path pairs of explicit imports to use.")
 	auxFiles               = flag.String("aux_files", "", "(source mode) Comma-separated pkg=path pairs of auxiliary Go source files.")
 	excludeInterfaces      = flag.String("exclude_interfaces", "", "Comma-separated names of interfaces to be excluded")
-	implType               = flag.String("implementation_type", "mock", "The type of code to generate (mock or trace).")
+	implType               = flag.String("implementation_type", "mock", "The type of code to generate (mock, trace, metrics, logging, retry, breaker, ratelimit, timeout, cache, singleflight, fallback, shadow, multi, stub, noop, unimplemented, record, chaos or synchronized).")
+	attributeArgs          = flag.String("attribute_args", "", "(trace, logging) Comma-separated interface names, Interface.Method pairs or * selecting the methods whose arguments are recorded.")
+	attributeResults       = flag.String("attribute_results", "", "(trace, logging) Comma-separated interface names, Interface.Method pairs or * selecting the methods whose results are recorded.")
+	attributeExclude       = flag.String("attribute_exclude", "", "(trace, logging) Comma-separated names of parameters that are never recorded.")
+	loggingSuccessLevel    = flag.String("logging_success_level", "debug", "(logging) Default level of the calls that succeed: debug, info, warn or error.")
+	loggingFailureLevel    = flag.String("logging_failure_level", "error", "(logging) Default level of the calls that fail: debug, info, warn or error.")
+	traceScope             = flag.String("trace_scope", "", "(trace) Instrumentation scope name of the tracer; defaults to the import path of the source package.")
+	traceSpanName          = flag.String("trace_span_name", defaultSpanName, "(trace) Template of the span names, with the {{.Scope}}, {{.Interface}} and {{.Method}} fields.")
+	traceSpanKind          = flag.String("trace_span_kind", "internal", "(trace) Kind of the spans: internal, server, client, producer or consumer.")
+	traceContextFree       = flag.String("trace_context_free", contextFreeNone, "(trace) How methods without a context.Context argument are traced: none, root (in a new root span) or link (in a new root span linked to the spans carried by the arguments).")
+	retryNonIdempotent     = flag.String("retry_non_idempotent", "", "(retry) Comma-separated interface names or Interface.Method pairs selecting the methods that are never retried, as the methods annotated with //implgen:nonidempotent.")
+	breakerScope           = flag.String("breaker_scope", "method", "(breaker) Default scope of the circuit breakers: method (a breaker per method) or interface (a breaker per interface).")
+	cacheMethods           = flag.String("cache_methods", "", "(cache) Comma-separated interface names, Interface.Method pairs or * selecting the methods whose results are cached, as the methods annotated with //implgen:cache.")
+	cacheInvalidate        = flag.String("cache_invalidate", "", "(cache) Comma-separated Interface.Method=Cached pairs, where the calls of Interface.Method purge the cached results of Cached, as with //implgen:invalidates Cached.")
+	singleflightMethods    = flag.String("singleflight_methods", "", "(singleflight) Comma-separated interface names, Interface.Method pairs or * selecting the methods whose concurrent calls with equal arguments are coalesced. The methods annotated with //implgen:nonidempotent are only selected by their Interface.Method pair.")
+	shadowMethods          = flag.String("shadow_methods", "", "(shadow) Comma-separated interface names, Interface.Method pairs or * selecting the methods whose calls are repeated on the shadow implementation. The methods annotated with //implgen:nonidempotent are only selected by their Interface.Method pair.")
+	stubUnset              = flag.String("stub_unset", "zero", "(stub) What the methods whose function is not set do: zero (return zero values) or panic.")
+	stubRecord             = flag.Bool("stub_record", false, "(stub) Record the arguments of the calls of every method.")
+	unimplementedGuard     = flag.Bool("unimplemented_guard", false, "(unimplemented) Generate a mustEmbed method, and an interface requiring it, enforcing the embedding of the unimplemented implementations.")
+	synchronizedReadOnly   = flag.String("synchronized_readonly", "", "(synchronized) Comma-separated interface names, Interface.Method pairs or * selecting the read-only methods, which take a read lock, as the methods annotated with //implgen:readonly.")
+	synchronizedContext    = flag.Bool("synchronized_context", false, "(synchronized) Acquire the locks of the methods with a context.Context argument and a result of type error with their context, failing with its error when it is done first.")
+	metricsBackendName     = flag.String("metrics_backend", "prometheus", "(metrics) Library the metrics are recorded with: prometheus (go-kit), otel or expvar.")
+	metricsNamespace       = flag.String("metrics_namespace", "", "(metrics) Namespace of the recorded metrics.")
+	metricsSubsystem       = flag.String("metrics_subsystem", "", "(metrics) Subsystem of the recorded metrics; defaults to the interface name.")
+	metricsDurationName    = flag.String("metrics_name", "duration", "(metrics) Name of the duration histogram; the unit is appended to it.")
+	metricsHelp            = flag.String("metrics_help", "", "(metrics) Help text of the duration histogram; defaults to one describing the interface.")
+	metricsUnitName        = flag.String("metrics_unit", "seconds", "(metrics) Unit of the recorded durations: seconds, milliseconds or microseconds.")
+	metricsBucketsSpec     = flag.String("metrics_buckets", "default", "(metrics) Duration histogram buckets: default, a comma-separated list of upper bounds, exponential:start,factor,count or linear:start,width,count.")
 
 	debugParser = flag.Bool("debug_parser", false, "Print out parser results only.")
 	showVersion = flag.Bool("version", false, "Print version.")
@@ -159,15 +188,173 @@ func main() {
 		g.copyrightHeader = string(header)
 	}
 
+	g.attributes = attributeOptions{
+		args:    parseSelectors(*attributeArgs),
+		results: parseSelectors(*attributeResults),
+		exclude: parseSelectors(*attributeExclude),
+	}
+
 	switch *implType {
 	case "metrics":
+		backend, ok := metricsBackends[*metricsBackendName]
+		if !ok {
+			log.Fatalf("Unknown metrics backend %q", *metricsBackendName)
+		}
 		g.gen = generateMetricsInterface
+		g.decorates = true
+		g.genImports = backend.imports()
+		g.metrics = metricsOptions{
+			backend:   backend,
+			namespace: *metricsNamespace,
+			subsystem: *metricsSubsystem,
+			name:      *metricsDurationName,
+			help:      *metricsHelp,
+			unit:      *metricsUnitName,
+			buckets:   *metricsBucketsSpec,
+		}
 		outputPrefix = "metrics"
 	case "trace":
+		switch *traceContextFree {
+		case contextFreeNone, contextFreeRoot, contextFreeLink:
+		default:
+			log.Fatalf("Unknown -trace_context_free mode %q", *traceContextFree)
+		}
 		g.gen = generateTracedInterface
+		g.decorates = true
+		g.genImports = traceImports
+		spanName, err := template.New("span_name").Parse(*traceSpanName)
+		if err != nil {
+			log.Fatalf("Bad -trace_span_name template: %v", err)
+		}
+		if _, ok := spanKinds[*traceSpanKind]; !ok {
+			log.Fatalf("Unknown -trace_span_kind %q", *traceSpanKind)
+		}
+		g.trace = traceOptions{
+			contextFree: *traceContextFree,
+			scope:       *traceScope,
+			spanName:    spanName,
+			spanKind:    *traceSpanKind,
+		}
 		outputPrefix = "trace"
-	case "mock":
+	case "logging":
+		for _, level := range []string{*loggingSuccessLevel, *loggingFailureLevel} {
+			if _, ok := slogLevels[level]; !ok {
+				log.Fatalf("Unknown logging level %q", level)
+			}
+		}
+		g.gen = generateLoggedInterface
+		g.decorates = true
+		g.genImports = loggedImports
+		g.logging = loggingOptions{
+			successLevel: *loggingSuccessLevel,
+			failureLevel: *loggingFailureLevel,
+		}
+		outputPrefix = "logging"
+	case "retry":
+		g.gen = generateRetryingInterface
+		g.decorates = true
+		g.genImports = retryImports
+		g.retry = retryOptions{
+			nonIdempotent: parseSelectors(*retryNonIdempotent),
+		}
+		outputPrefix = "retry"
+	case "breaker":
+		if _, ok := breakerScopes[*breakerScope]; !ok {
+			log.Fatalf("Unknown -breaker_scope %q", *breakerScope)
+		}
+		g.gen = generateBreakingInterface
+		g.decorates = true
+		g.genImports = breakerImports
+		g.breaker = breakerOptions{scope: *breakerScope}
+		outputPrefix = "breaker"
+	case "ratelimit":
+		g.gen = generateLimitedInterface
+		g.decorates = true
+		g.genImports = ratelimitImports
+		outputPrefix = "ratelimit"
+	case "timeout":
+		g.gen = generateTimeoutInterface
+		g.decorates = true
+		g.genImports = timeoutImports
+		outputPrefix = "timeout"
+	case "cache":
+		invalidate, err := parseInvalidations(*cacheInvalidate)
+		if err != nil {
+			log.Fatalf("Bad -cache_invalidate: %v", err)
+		}
+		g.gen = generateCachedInterface
+		g.decorates = true
+		g.genImports = cacheImports
+		g.cache = cacheOptions{
+			methods:    parseSelectors(*cacheMethods),
+			invalidate: invalidate,
+		}
+		outputPrefix = "cache"
+	case "singleflight":
+		g.gen = generateCoalescedInterface
+		g.decorates = true
+		g.genImports = singleflightImports
+		g.singleflight = singleflightOptions{methods: parseSelectors(*singleflightMethods)}
+		outputPrefix = "singleflight"
+	case "fallback":
+		g.gen = generateFallbackInterface
+		g.decorates = true
+		g.genImports = fallbackImports
+		outputPrefix = "fallback"
+	case "shadow":
+		g.gen = generateShadowedInterface
+		g.decorates = true
+		g.genImports = shadowImports
+		g.shadow = shadowOptions{methods: parseSelectors(*shadowMethods)}
+		outputPrefix = "shadow"
+	case "multi":
+		g.gen = generateMultiInterface
+		g.decorates = true
+		g.genImports = multiImports
+		outputPrefix = "multi"
+	case "stub":
+		if *stubUnset != "zero" && *stubUnset != "panic" {
+			log.Fatalf("Unknown -stub_unset %q", *stubUnset)
+		}
+		g.gen = generateStubInterface
+		g.decorates = true
+		g.genImports = stubImports
+		g.stub = stubOptions{panics: *stubUnset == "panic", record: *stubRecord}
+		outputPrefix = "stub"
+	case "noop":
+		g.gen = generateNoopInterface
+		g.decorates = true
+		outputPrefix = "noop"
+	case "unimplemented":
+		g.gen = generateUnimplementedInterface
+		g.decorates = true
+		g.genImports = unimplementedImports
+		g.unimplemented = unimplementedOptions{guard: *unimplementedGuard}
+		outputPrefix = "unimplemented"
+	case "record":
+		g.gen = generateRecordingInterface
+		g.decorates = true
+		g.genImports = recordImports
+		outputPrefix = "record"
+	case "chaos":
+		g.gen = generateChaosInterface
+		g.decorates = true
+		g.genImports = chaosImports
+		outputPrefix = "chaos"
+	case "synchronized":
+		g.gen = generateSynchronizedInterface
+		g.decorates = true
+		g.genImports = synchronizedImports
+		g.synchronized = synchronizedOptions{
+			readOnly: parseSelectors(*synchronizedReadOnly),
+			context:  *synchronizedContext,
+		}
+		outputPrefix = "synchronized"
 	default:
+		// Unknown types generate mocks, as mock is the default type.
+		if *implType != "mock" {
+			log.Printf("Warning: unknown -implementation_type %q, generating mocks", *implType)
+		}
 		g.gen = generateMockInterface
 		outputPrefix = "mock"
 	}
@@ -267,9 +454,25 @@ type generator struct {
 	destination               string            // may be empty
 	srcPackage, srcInterfaces string            // may be empty
 	copyrightHeader           string
-
-	packageMap map[string]string // map from import path to package name
-	gen        func(*generator, *model.Interface, string) error
+	pkgPath                   string // import path of the package of the interfaces; may be empty
+
+	packageMap    map[string]string // map from import path to package name
+	gen           func(*generator, *model.Interface, string) error
+	genImports    map[string]string // import paths used by gen, to preferred package name (may be empty)
+	decorates     bool              // whether gen wraps the source interfaces
+	metrics       metricsOptions
+	trace         traceOptions
+	logging       loggingOptions
+	retry         retryOptions
+	breaker       breakerOptions
+	cache         cacheOptions
+	singleflight  singleflightOptions
+	shadow        shadowOptions
+	stub          stubOptions
+	unimplemented unimplementedOptions
+	synchronized  synchronizedOptions
+	attributes    attributeOptions
+	resolver      typeResolver // resolves the named types of the interfaces
 }
 
 func (g *generator) p(format string, args ...any) {
@@ -315,6 +518,12 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 		outputPackagePath = ""
 	}
 
+	g.pkgPath = pkg.PkgPath
+	if g.pkgPath == "" {
+		// Reflect mode does not record the import path in the package.
+		g.pkgPath = g.srcPackage
+	}
+
 	if g.copyrightHeader != "" {
 		lines := strings.Split(g.copyrightHeader, "\n")
 		for _, line := range lines {
@@ -347,6 +556,13 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	// Get all required imports, and generate unique names for them all.
 	im := pkg.Imports()
 	im[gomockImportPath] = true
+	for pth := range g.genImports {
+		im[pth] = true
+	}
+	if g.decorates && g.pkgPath != "" && g.pkgPath != outputPackagePath {
+		// Decorators refer to the interfaces they wrap.
+		im[g.pkgPath] = true
+	}
 
 	// Only import reflect if it's used. We only use reflect in mocked methods
 	// so only import if any of the mocked interfaces have methods.
@@ -385,6 +601,9 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 		if !ok {
 			base = sanitize(path.Base(pth))
 		}
+		if name := g.genImports[pth]; name != "" {
+			base = name
+		}
 
 		// Local names for an imported package can usually be the basename of the import path.
 		// A couple of situations don't permit that, such as duplicate local names
@@ -467,6 +686,48 @@ func (g *generator) mockName(typeName string) string {
 	return "Mock" + typeName
 }
 
+// qualify returns the exported identifier name of the package at importPath,
+// qualified by the local name under which the generated code imports it.
+func (g *generator) qualify(importPath, name string) string {
+	if pkgName := g.packageMap[importPath]; pkgName != "" {
+		return pkgName + "." + name
+	}
+	return name
+}
+
+// sourceInterface returns the type of the source interface intf, as referred
+// to by the decorators of the output package, given its type arguments.
+func (g *generator) sourceInterface(intf *model.Interface, outputPackagePath, typeArgs string) string {
+	if g.pkgPath == outputPackagePath {
+		return intf.Name + typeArgs
+	}
+	return g.qualify(g.pkgPath, intf.Name) + typeArgs
+}
+
+// generateInterfaceAssertion writes the declaration asserting at compile
+// time that the decorator mockType implements intfType. Generic decorators
+// are not asserted, as that needs type arguments.
+func (g *generator) generateInterfaceAssertion(intf *model.Interface, intfType, mockType string) {
+	if len(intf.TypeParams) > 0 {
+		return
+	}
+	g.p("var _ %v = (*%v)(nil)", intfType, mockType)
+	g.p("")
+}
+
+// unexported returns name with its first letter in lower case, for
+// declarations that are private to the generated code.
+func unexported(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// exported returns name with its first letter in upper case.
+func exported(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
 // formattedTypeParams returns a long and short form of type param info used for
 // printing. If analyzing a interface with type param [I any, O any] the result
 // will be:
@@ -795,9 +1056,37 @@ func (g *generator) getArgNames(m *model.Method, in bool) []string {
 		}
 		argNames = append(argNames, name)
 	}
+	if g.decorates {
+		g.unshadow(argNames)
+	}
 	return argNames
 }
 
+// unshadow renames the arguments that would shadow an imported package in
+// the body of a decorator method, to names taken by neither the other
+// arguments nor the packages.
+func (g *generator) unshadow(argNames []string) {
+	var shadowing []int
+	for i, name := range argNames {
+		for _, pkgName := range g.packageMap {
+			if pkgName == name {
+				shadowing = append(shadowing, i)
+				break
+			}
+		}
+	}
+	if len(shadowing) == 0 {
+		return
+	}
+	ia := newIdentifierAllocator(argNames)
+	for _, pkgName := range g.packageMap {
+		ia.allocateIdentifier(pkgName)
+	}
+	for _, i := range shadowing {
+		argNames[i] = ia.allocateIdentifier(argNames[i] + "_")
+	}
+}
+
 func (g *generator) getArgTypes(m *model.Method, pkgOverride string, in bool) []string {
 	var params []*model.Parameter
 	if in {
@@ -276,9 +276,10 @@ func findMethod(t *testing.T, identifier, methodName string, lines []string) int
 
 func TestGetArgNames(t *testing.T) {
 	for _, testCase := range []struct {
-		name     string
-		method   *model.Method
-		expected []string
+		name      string
+		method    *model.Method
+		decorates bool
+		expected  []string
 	}{
 		{
 			name: "NamedArg",
@@ -328,9 +329,58 @@ func TestGetArgNames(t *testing.T) {
 			},
 			expected: []string{"firstArg", "arg1"},
 		},
+		{
+			name: "PackageNameArg",
+			method: &model.Method{
+				In: []*model.Parameter{
+					{
+						Name: "time",
+						Type: &model.NamedType{Package: "time", Type: "Duration"},
+					},
+				},
+			},
+			decorates: true,
+			expected:  []string{"time_"},
+		},
+		{
+			name: "PackageNameArgCollision",
+			method: &model.Method{
+				In: []*model.Parameter{
+					{
+						Name: "time",
+						Type: &model.NamedType{Package: "time", Type: "Duration"},
+					},
+					{
+						Name: "time_",
+						Type: &model.NamedType{Type: "string"},
+					},
+				},
+				Variadic: &model.Parameter{
+					Name: "time__2",
+					Type: &model.NamedType{Type: "int"},
+				},
+			},
+			decorates: true,
+			expected:  []string{"time__3", "time_", "time__2"},
+		},
+		{
+			name: "PackageNameArgMock",
+			method: &model.Method{
+				In: []*model.Parameter{
+					{
+						Name: "time",
+						Type: &model.NamedType{Package: "time", Type: "Duration"},
+					},
+				},
+			},
+			expected: []string{"time"},
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
-			g := generator{}
+			g := generator{
+				packageMap: map[string]string{"time": "time"},
+				decorates:  testCase.decorates,
+			}
 
 			result := g.getArgNames(testCase.method, true)
 			if !reflect.DeepEqual(result, testCase.expected) {
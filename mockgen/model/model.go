@@ -90,6 +90,10 @@ type Method struct {
 	Name     string
 	In, Out  []*Parameter
 	Variadic *Parameter // may be nil
+
+	// Directives are the //implgen: comments of the method, without their
+	// prefix, in source mode.
+	Directives []string
 }
 
 // Print writes the method name and its signature.
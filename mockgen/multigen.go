@@ -0,0 +1,233 @@
+package main
+
+// This file contains the multiplexer, which fans the calls of an interface
+// out to several implementations with the multi package.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const multiImportPath = "github.com/pableeee/implgen/multi"
+
+// multiImports are the packages referenced by the multiplexer.
+var multiImports = map[string]string{
+	"errors":        "",
+	multiImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) multiName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Multi" + typeName
+}
+
+// mergesResults reports whether the results of m on the implementations of
+// a multiplexer are merged into one, rather than broadcast or joined.
+func mergesResults(m *model.Method) bool {
+	return len(m.Out) > 1 || len(m.Out) == 1 && !returnsError(m)
+}
+
+func generateMultiInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.multiName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	strategyType := g.qualify(multiImportPath, "Strategy")
+
+	sort.Sort(byMethodName(intf.Methods))
+	var merged []*model.Method
+	for _, m := range intf.Methods {
+		if mergesResults(m) {
+			merged = append(merged, m)
+		}
+	}
+	mergeTypes := make(map[string]string)
+	for _, m := range merged {
+		_, retString := g.makeRetString(m, outputPackagePath)
+		mergeTypes[m.Name] = fmt.Sprintf("func(results []%v%v)%v", mockType+m.Name+"Result", shortTp, retString)
+	}
+
+	g.p("")
+	g.p("// %v is a multiplexer of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("impls    []%v", intfType)
+	g.p("parallel bool")
+	g.p("strategy %v", strategyType)
+	for _, m := range merged {
+		g.p("merge%v %v", m.Name, mergeTypes[m.Name])
+	}
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	for _, m := range merged {
+		rets, _ := g.makeRetString(m, outputPackagePath)
+		resType := mockType + m.Name + "Result"
+		g.p("// %v holds the results of a call of %v on an implementation", resType, m.Name)
+		g.p("// of a %v.", mockType)
+		g.p("type %v%v struct {", resType, longTp)
+		g.in()
+		for i, ret := range rets {
+			g.p("R%d %v", i, ret)
+		}
+		g.out()
+		g.p("}")
+		g.p("")
+	}
+
+	opts := []decoratorOption{
+		{
+			name:  "Parallel",
+			param: "parallel",
+			field: "parallel",
+			typ:   "bool",
+			doc: "sets whether the implementations of a " + mockType + "\n" +
+				"are called in parallel, rather than in order. Defaults to in order.",
+		},
+		{
+			name:  "Strategy",
+			param: "strategy",
+			field: "strategy",
+			typ:   strategyType,
+			doc: "sets the strategy selecting the results returned by\n" +
+				"the methods of a " + mockType + " with results other than an error.\n" +
+				"Every implementation is called, whatever the strategy. Defaults to\n" +
+				"multi.FirstSuccess.",
+		},
+	}
+	for _, m := range merged {
+		opts = append(opts, decoratorOption{
+			name:  "Merge" + m.Name,
+			param: "merge",
+			field: "merge" + m.Name,
+			typ:   mergeTypes[m.Name],
+			doc: "sets the function merging the results of the calls of\n" +
+				m.Name + " on the implementations of a " + mockType + ", in their\n" +
+				"order, in place of its strategy.",
+		})
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new multiplexer instance, calling every one of impls.", mockType)
+	g.p("func New%v%v(impls []%v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("impls:    impls,")
+	g.p("parallel: o.parallel,")
+	g.p("strategy: o.strategy,")
+	for _, m := range merged {
+		g.p("merge%v: o.merge%v,", m.Name, m.Name)
+	}
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateMultiMethod(g, mockType, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateMultiMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+	idIndex := ia.allocateIdentifier("i")
+	dispatch := func(body string) {
+		g.p("%v(len(%v.impls), %v.parallel, func(%v int) {", g.qualify(multiImportPath, "Dispatch"), idRecv, idRecv, idIndex)
+		g.in()
+		g.p("%v", body)
+		g.out()
+		g.p("})")
+	}
+	call := fmt.Sprintf("%v.impls[%v].%v(%v)", idRecv, idIndex, m.Name, callArgs)
+
+	g.p("// %v multiplexer base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	defer func() {
+		g.out()
+		g.p("}")
+	}()
+
+	// Void methods are broadcast, and the errors of the methods returning
+	// only an error are joined.
+	if len(rets) == 0 {
+		dispatch(call)
+		return
+	}
+	if !mergesResults(m) {
+		idErrs := ia.allocateIdentifier("errs")
+		g.p("%v := make([]error, len(%v.impls))", idErrs, idRecv)
+		dispatch(fmt.Sprintf("%v[%v] = %v", idErrs, idIndex, call))
+		g.p("return %v(%v...)", g.qualify("errors", "Join"), idErrs)
+		return
+	}
+
+	idResults := ia.allocateIdentifier("results")
+	idRes := ia.allocateIdentifier("r")
+	fields := make([]string, len(rets))
+	resFields := make([]string, len(rets))
+	returns := make([]string, len(rets))
+	for i := range rets {
+		fields[i] = fmt.Sprintf("%v[%v].R%d", idResults, idIndex, i)
+		resFields[i] = fmt.Sprintf("%v.R%d", idRes, i)
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+	g.p("%v := make([]%v%v, len(%v.impls))", idResults, mockType+m.Name+"Result", shortTp, idRecv)
+	dispatch(fmt.Sprintf("%v = %v", strings.Join(fields, ", "), call))
+	g.p("if %v.merge%v != nil {", idRecv, m.Name)
+	g.in()
+	g.p("return %v.merge%v(%v)", idRecv, m.Name, idResults)
+	g.out()
+	g.p("}")
+
+	if !returnsError(m) {
+		// Without an error, the first results are a success.
+		g.p("if len(%v) > 0 {", idResults)
+		g.in()
+		g.p("%v := %v[0]", idRes, idResults)
+		g.p("return %v", strings.Join(resFields, ", "))
+		g.out()
+		g.p("}")
+		for i, ret := range rets {
+			g.p("var %v %v", returns[i], ret)
+		}
+		g.p("return %v", strings.Join(returns, ", "))
+		return
+	}
+
+	idErrs := ia.allocateIdentifier("errs")
+	errField := resFields[errorResultOf(m).index]
+	g.p("var %v []error", idErrs)
+	g.p("for _, %v := range %v {", idRes, idResults)
+	g.in()
+	g.p("if %v == nil || %v.strategy == %v {", errField, idRecv, g.qualify(multiImportPath, "FirstResult"))
+	g.in()
+	g.p("return %v", strings.Join(resFields, ", "))
+	g.out()
+	g.p("}")
+	g.p("%v = append(%v, %v)", idErrs, idErrs, errField)
+	g.out()
+	g.p("}")
+	g.generateErrorReturn(m, rets, returns, fmt.Sprintf("%v(%v...)", g.qualify("errors", "Join"), idErrs))
+}
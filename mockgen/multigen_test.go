@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateMultiInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", "errors": "errors", multiImportPath: "multi"},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateMultiInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"impls    []Store",
+		"mergeGet func(results []MultiStoreGetResult) (string, error)",
+		"type MultiStoreGetResult struct {\n\tR0 string\n\tR1 error\n}",
+		"func WithMultiStoreMergeLen(merge func(results []MultiStoreLenResult) int) MultiStoreOption {",
+		"func NewMultiStore(impls []Store, opts ...MultiStoreOption) *MultiStore {",
+		"multi.Dispatch(len(t.impls), t.parallel, func(i int) {\n\t\tt.impls[i].Close()\n\t})",
+		"errs := make([]error, len(t.impls))",
+		"errs[i] = t.impls[i].Put(ctx, key)",
+		"return errors.Join(errs...)",
+		"results[i].R0, results[i].R1 = t.impls[i].Get(ctx, key)",
+		"if t.mergeGet != nil {\n\t\treturn t.mergeGet(results)\n\t}",
+		"if r.R1 == nil || t.strategy == multi.FirstResult {\n\t\t\treturn r.R0, r.R1\n\t\t}",
+		"var ret string\n\treturn ret, errors.Join(errs...)",
+		"if len(results) > 0 {\n\t\tr := results[0]\n\t\treturn r.R0\n\t}\n\tvar ret int\n\treturn ret",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"MultiStorePutResult", "MultiStoreCloseResult"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("generated code contains %q:\n%s", unwanted, out)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+// This file contains the no-op implementation, whose methods do nothing and
+// return zero values.
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) noopName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Noop" + typeName
+}
+
+func generateNoopInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.noopName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+
+	g.p("")
+	g.p("// %v is a no-op implementation of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("err error")
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "Error",
+			param: "err",
+			field: "err",
+			typ:   "error",
+			doc: "sets the error returned by the methods of a\n" +
+				mockType + " with a result of type error. Defaults to nil.",
+		},
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new no-op implementation instance.", mockType)
+	g.p("func New%v%v(opts ...%v) *%v%v {", mockType, longTp, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("return &%v%v{err: o.err}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateNoopMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateNoopMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v no-op base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if len(rets) > 0 {
+		errIndex := -1
+		if returnsError(m) {
+			errIndex = errorResultOf(m).index
+		}
+		returns := make([]string, len(rets))
+		for i, ret := range rets {
+			if i == errIndex {
+				returns[i] = idRecv + ".err"
+				continue
+			}
+			returns[i] = ia.allocateIdentifier("ret")
+
+			// The channels that can be received from are closed, so that
+			// the callers do not block.
+			ct, ok := m.Out[i].Type.(*model.ChanType)
+			switch {
+			case !ok:
+				g.p("var %v %v", returns[i], ret)
+			case ct.Dir == model.SendDir:
+				log.Printf("Warning: %v.%v returns a send-only channel, which is nil", intf.Name, m.Name)
+				g.p("var %v %v", returns[i], ret)
+			default:
+				g.p("%v := make(chan %v)", returns[i], ct.Type.String(g.packageMap, pkgOverride))
+				g.p("close(%v)", returns[i])
+			}
+		}
+		g.p("return %v", strings.Join(returns, ", "))
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateNoopInterface(t *testing.T) {
+	g := generator{packageMap: map[string]string{"context": "context"}}
+	intf := &model.Interface{Name: "Sink"}
+	intf.AddMethod(&model.Method{
+		Name: "Send",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "event", Type: model.PredeclaredType("string")},
+		},
+		Out: []*model.Parameter{{Type: model.PredeclaredType("int")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Events",
+		Out: []*model.Parameter{
+			{Type: &model.ChanType{Dir: model.RecvDir, Type: model.PredeclaredType("string")}},
+			{Type: &model.ChanType{Dir: model.SendDir, Type: model.PredeclaredType("string")}},
+		},
+	})
+	intf.AddMethod(&model.Method{Name: "Flush"})
+
+	if err := generateNoopInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewNoopSink(opts ...NoopSinkOption) *NoopSink {",
+		"func WithNoopSinkError(err error) NoopSinkOption {",
+		"return &NoopSink{err: o.err}",
+		"func (t *NoopSink) Send(ctx context.Context, event string) (int, error) {\n\tvar ret int\n\treturn ret, t.err\n}",
+		"ret := make(chan string)\n\tclose(ret)\n\tvar ret_2 chan<- string\n\treturn ret, ret_2",
+		"func (t *NoopSink) Flush() {\n}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateNoopInterface_Generic(t *testing.T) {
+	g := generator{packageMap: map[string]string{"example.com/store": "store"}, pkgPath: "example.com/store"}
+	intf := &model.Interface{
+		Name:       "Repository",
+		TypeParams: []*model.Parameter{{Name: "V", Type: model.PredeclaredType("any")}},
+	}
+	intf.AddMethod(&model.Method{
+		Name: "Watch",
+		Out:  []*model.Parameter{{Type: &model.ChanType{Type: model.PredeclaredType("V")}}},
+	})
+
+	if err := generateNoopInterface(&g, intf, "example.com/store/noop"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"type NoopRepository[V any] struct {",
+		"func NewNoopRepository[V any](opts ...NoopRepositoryOption) *NoopRepository[V] {",
+		"return &NoopRepository[V]{err: o.err}",
+		"func (t *NoopRepository[V]) Watch() chan V {\n\tret := make(chan V)\n\tclose(ret)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
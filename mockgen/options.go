@@ -0,0 +1,96 @@
+package main
+
+// This file contains the functional options of the constructors of the
+// generated decorators.
+
+import "strings"
+
+// decoratorOption is a functional option of the constructor of a decorator,
+// setting a field of its options struct.
+type decoratorOption struct {
+	name  string // option function name, without the With<Type> prefix
+	param string // name of the parameter of the option function
+	field string // field of the options struct
+	typ   string // type of the field
+	def   string // expression of the default value; the zero value if empty
+	doc   string // doc comment of the option function, after its name
+}
+
+// optionsTypes returns the names of the option type of the decorator
+// mockType and of its unexported options struct.
+func optionsTypes(mockType string) (option, options string) {
+	return mockType + "Option", unexported(mockType) + "Options"
+}
+
+// generateOptions writes the option type of the decorator mockType, its
+// options struct and the functions returning opts.
+func (g *generator) generateOptions(mockType string, opts []decoratorOption) {
+	optionType, optionsType := optionsTypes(mockType)
+
+	g.p("// %v configures a %v.", optionType, mockType)
+	g.p("type %v func(*%v)", optionType, optionsType)
+	g.p("")
+	g.p("type %v struct {", optionsType)
+	g.in()
+	for _, o := range opts {
+		g.p("%v %v", o.field, o.typ)
+	}
+	g.out()
+	g.p("}")
+
+	for _, o := range opts {
+		g.p("")
+		fn := "With" + mockType + o.name
+		for i, line := range strings.Split(o.doc, "\n") {
+			if i == 0 {
+				line = fn + " " + line
+			}
+			g.p("// %v", line)
+		}
+		g.p("func %v(%v %v) %v {", fn, o.param, o.typ, optionType)
+		g.in()
+		g.p("return func(o *%v) {", optionsType)
+		g.in()
+		g.p("o.%v = %v", o.field, o.param)
+		g.out()
+		g.p("}")
+		g.out()
+		g.p("}")
+	}
+	g.p("")
+}
+
+// generateApplyOptions writes the constructor statements declaring the
+// options of the decorator mockType in o, with the defaults applied and
+// then the options passed as opts.
+func (g *generator) generateApplyOptions(mockType string, opts []decoratorOption) {
+	_, optionsType := optionsTypes(mockType)
+
+	var defaults []string
+	for _, o := range opts {
+		if o.def != "" {
+			defaults = append(defaults, o.field+": "+o.def)
+		}
+	}
+	g.p("o := %v{%v}", optionsType, strings.Join(defaults, ", "))
+	g.p("for _, opt := range opts {")
+	g.in()
+	g.p("opt(&o)")
+	g.out()
+	g.p("}")
+}
+
+// errorClassifierOption returns the option setting the function of the
+// decorator mockType that reports whether an error is a failure.
+func errorClassifierOption(mockType string) decoratorOption {
+	return decoratorOption{
+		name:  "ErrorClassifier",
+		param: "isFailure",
+		field: "isFailure",
+		typ:   "func(err error) bool",
+		def:   "func(error) bool { return true }",
+		doc: "sets the function reporting whether an error\n" +
+			"returned by the delegate of a " + mockType + " is a failure, such as\n" +
+			"to ignore context.Canceled. Defaults to reporting every error as a failure.",
+	}
+}
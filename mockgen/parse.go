@@ -48,7 +48,7 @@ func sourceMode(source string) (*model.Package, error) {
 	}
 
 	fs := token.NewFileSet()
-	file, err := parser.ParseFile(fs, source, nil, 0)
+	file, err := parser.ParseFile(fs, source, nil, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing source file %v: %v", source, err)
 	}
@@ -189,7 +189,7 @@ func (p *fileParser) parseAuxFiles(auxFiles string) error {
 		}
 		pkg, fpath := parts[0], parts[1]
 
-		file, err := parser.ParseFile(p.fileSet, fpath, nil, 0)
+		file, err := parser.ParseFile(p.fileSet, fpath, nil, parser.ParseComments)
 		if err != nil {
 			return err
 		}
@@ -262,7 +262,7 @@ func (p *fileParser) parsePackage(path string) (*fileParser, error) {
 	var pkgs map[string]*ast.Package
 	if imp, err := build.Import(path, newP.srcDir, build.FindOnly); err != nil {
 		return nil, err
-	} else if pkgs, err = parser.ParseDir(newP.fileSet, imp.Dir, nil, 0); err != nil {
+	} else if pkgs, err = parser.ParseDir(newP.fileSet, imp.Dir, nil, parser.ParseComments); err != nil {
 		return nil, err
 	}
 
@@ -347,6 +347,24 @@ func (p *fileParser) parseInterface(name, pkg string, it *namedInterface) (*mode
 	return iface, nil
 }
 
+// directivePrefix is the prefix of the comments of the interface methods
+// that direct the generated decorators, e.g. //implgen:nonidempotent.
+const directivePrefix = "//implgen:"
+
+// parseDirectives returns the directives of the doc comment of a method.
+func parseDirectives(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	var directives []string
+	for _, c := range doc.List {
+		if d, ok := strings.CutPrefix(c.Text, directivePrefix); ok {
+			directives = append(directives, strings.TrimSpace(d))
+		}
+	}
+	return directives
+}
+
 func (p *fileParser) parseMethod(field *ast.Field, it *namedInterface, iface *model.Interface, pkg string, tps map[string]model.Type) ([]*model.Method, error) {
 	// {} for git diff
 	{
@@ -356,7 +374,8 @@ func (p *fileParser) parseMethod(field *ast.Field, it *namedInterface, iface *mo
 				return nil, fmt.Errorf("expected one name for interface %v, got %d", iface.Name, nn)
 			}
 			m := &model.Method{
-				Name: field.Names[0].String(),
+				Name:       field.Names[0].String(),
+				Directives: parseDirectives(field.Doc),
 			}
 			var err error
 			m.In, m.Variadic, m.Out, err = p.parseFunc(pkg, v, tps)
@@ -3,6 +3,7 @@ package main
 import (
 	"go/parser"
 	"go/token"
+	"reflect"
 	"testing"
 )
 
@@ -143,3 +144,48 @@ func TestParseArrayWithConstLength(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDirectives(t *testing.T) {
+	fs := token.NewFileSet()
+	src := `package p
+
+type Store interface {
+	// Get gets a value.
+	//implgen:idempotent
+	Get(key string) (string, error)
+	// Put puts a value.
+	//implgen:nonidempotent
+	//implgen: readonly
+	Put(key, value string) error
+}
+`
+	file, err := parser.ParseFile(fs, "store.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+		auxInterfaces:      newInterfaceCache(),
+	}
+
+	pkg, err := p.parseFile("", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, m := range pkg.Interfaces[0].Methods {
+		var want []string
+		switch m.Name {
+		case "Get":
+			want = []string{"idempotent"}
+		case "Put":
+			want = []string{"nonidempotent", "readonly"}
+		}
+		if !reflect.DeepEqual(m.Directives, want) {
+			t.Errorf("%v directives = %q, want %q", m.Name, m.Directives, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+// This file contains the rate limiting decorator, which limits the rate and
+// the concurrency of the calls of every method with the ratelimit package.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const ratelimitImportPath = "github.com/pableeee/implgen/ratelimit"
+
+// ratelimitImports are the packages referenced by the rate limiting decorator.
+var ratelimitImports = map[string]string{
+	ratelimitImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) limitedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Limited" + typeName + "Impl"
+}
+
+func generateLimitedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.limitedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	limiterType := "*" + g.qualify(ratelimitImportPath, "Limiter")
+	limitsType := g.qualify(ratelimitImportPath, "Limits")
+
+	sort.Sort(byMethodName(intf.Methods))
+	var limited []string
+	for _, m := range intf.Methods {
+		// Methods that cannot return the rejections are not limited.
+		if returnsError(m) {
+			limited = append(limited, fmt.Sprintf("%q", m.Name))
+		} else {
+			log.Printf("Warning: %v.%v has no result of type error and is not limited", intf.Name, m.Name)
+		}
+	}
+
+	g.p("")
+	g.p("// %v is a rate limiting decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("limiters map[string]%v", limiterType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "MethodLimits",
+			param: "limits",
+			field: "methodLimits",
+			typ:   "map[string]" + limitsType,
+			doc: "sets the limits of the methods of a " + mockType + ",\n" +
+				"by method name, in place of the limits passed to its constructor.",
+		},
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new rate limiting decorator instance, limiting the", mockType)
+	g.p("// calls of every method with limits.")
+	g.p("func New%v%v(ctrl %v, limits %v, opts ...%v) *%v%v {", mockType, longTp, intfType, limitsType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate: ctrl,")
+	g.p("limiters: %v(limits, o.methodLimits, %q%v),", g.qualify(ratelimitImportPath, "NewMethods"), intf.Name, strings.Join(append([]string{""}, limited...), ", "))
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateLimitedMethod(g, mockType, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateLimitedMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v rate limiting base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if returnsError(m) {
+		idRelease := ia.allocateIdentifier("release")
+		idErr := ia.allocateIdentifier("err")
+		returns := make([]string, len(rets))
+		for i := range rets {
+			returns[i] = ia.allocateIdentifier("ret")
+		}
+
+		// Calls with a context wait for the limiter, the others fail fast.
+		acquire := "TryAcquire()"
+		if i := contextArgIndex(m); i >= 0 {
+			acquire = fmt.Sprintf("Acquire(%v)", argNames[i])
+		}
+		g.p("%v, %v := %v.limiters[%q].%v", idRelease, idErr, idRecv, m.Name, acquire)
+		g.p("if %v != nil {", idErr)
+		g.in()
+		g.generateErrorReturn(m, rets, returns, idErr)
+		g.out()
+		g.p("}")
+		g.p("defer %v()", idRelease)
+	}
+	if len(rets) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	}
+	g.out()
+	g.p("}")
+}
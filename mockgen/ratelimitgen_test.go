@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateLimitedInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", ratelimitImportPath: "ratelimit"},
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("string")},
+		},
+		Out: []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{{Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateLimitedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewLimitedStoreImpl(ctrl Store, limits ratelimit.Limits, opts ...LimitedStoreImplOption) *LimitedStoreImpl {",
+		`limiters: ratelimit.NewMethods(limits, o.methodLimits, "Store", "Get", "Put"),`,
+		`release, err := t.limiters["Get"].Acquire(ctx)`,
+		"return ret, err",
+		`release, err := t.limiters["Put"].TryAcquire()`,
+		"defer release()",
+		"t.delegate.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
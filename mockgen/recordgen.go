@@ -0,0 +1,196 @@
+package main
+
+// This file contains the recording decorator, which records the calls of an
+// implementation, and the replay serving them, with the record package.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const recordImportPath = "github.com/pableeee/implgen/record"
+
+// recordImports are the packages referenced by the recording decorator and
+// the replay.
+var recordImports = map[string]string{
+	"io":             "",
+	recordImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) recordingName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Recording" + typeName + "Impl"
+}
+
+// recordedValues returns the arguments of m that are recorded, all but its
+// context, and the index of its result of type error that is recorded as a
+// message, or -1.
+func recordedValues(m *model.Method, argNames []string) (args []string, errIndex int) {
+	ctxIndex := contextArgIndex(m)
+	args = []string{}
+	for i, name := range argNames {
+		if i != ctxIndex {
+			args = append(args, name)
+		}
+	}
+	errIndex = -1
+	if returnsError(m) {
+		errIndex = errorResultOf(m).index
+	}
+	return args, errIndex
+}
+
+func generateRecordingInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.recordingName(intf.Name)
+	replayType := "Replay" + intf.Name
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	recorderType := "*" + g.qualify(recordImportPath, "Recorder")
+	replayerType := "*" + g.qualify(recordImportPath, "Replayer")
+
+	g.p("")
+	g.p("// %v is a recording decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("recorder %v", recorderType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	g.p("// New%v creates a new recording decorator instance, recording the", mockType)
+	g.p("// calls with recorder. Defaults to discarding them if recorder is nil.")
+	g.p("func New%v%v(ctrl %v, recorder %v) *%v%v {", mockType, longTp, intfType, recorderType, mockType, shortTp)
+	g.in()
+	g.p("if recorder == nil {")
+	g.in()
+	g.p("recorder = %v(%v, nil)", g.qualify(recordImportPath, "NewRecorder"), g.qualify("io", "Discard"))
+	g.out()
+	g.p("}")
+	g.p("return &%v%v{delegate: ctrl, recorder: recorder}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateRecordingMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+
+	g.p("")
+	g.p("// %v replays the calls of %v interface recorded by a", replayType, intf.Name)
+	g.p("// %v.", mockType)
+	g.p("type %v%v struct {", replayType, longTp)
+	g.in()
+	g.p("replayer %v", replayerType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, replayType)
+
+	g.p("// New%v creates a new replay instance, replaying the calls with", replayType)
+	g.p("// replayer.")
+	g.p("func New%v%v(replayer %v) *%v%v {", replayType, longTp, replayerType, replayType, shortTp)
+	g.in()
+	g.p("return &%v%v{replayer: replayer}", replayType, shortTp)
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateReplayMethod(g, replayType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateRecordingMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+	args, errIndex := recordedValues(m, argNames)
+	returns := make([]string, len(rets))
+	var results []string
+	err := "nil"
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+		if i == errIndex {
+			err = returns[i]
+		} else {
+			results = append(results, returns[i])
+		}
+	}
+
+	g.p("// %v recording base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if len(rets) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+	}
+	g.p("%v.recorder.Record(%q, []any{%v}, []any{%v}, %v)", idRecv, intf.Name+"."+m.Name, strings.Join(args, ", "), strings.Join(results, ", "), err)
+	if len(rets) > 0 {
+		g.p("return %v", strings.Join(returns, ", "))
+	}
+	g.out()
+	g.p("}")
+}
+
+func generateReplayMethod(g *generator, replayType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+	args, errIndex := recordedValues(m, argNames)
+	returns := make([]string, len(rets))
+	results := []string{""}
+	for i := range rets {
+		if i != errIndex {
+			returns[i] = ia.allocateIdentifier("ret")
+			results = append(results, "&"+returns[i])
+		}
+	}
+
+	g.p("// %v replay base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, replayType, shortTp, m.Name, argString, retString)
+	g.in()
+	for i, ret := range rets {
+		if i != errIndex {
+			g.p("var %v %v", returns[i], ret)
+		}
+	}
+	replay := fmt.Sprintf("%v.replayer.Replay(%q, []any{%v}%v)", idRecv, intf.Name+"."+m.Name, strings.Join(args, ", "), strings.Join(results, ", "))
+	if errIndex >= 0 {
+		// The replay failures are returned as the recorded errors.
+		returns[errIndex] = ia.allocateIdentifier("err")
+		g.p("%v := %v", returns[errIndex], replay)
+	} else {
+		idErr := ia.allocateIdentifier("err")
+		g.p("if %v := %v; %v != nil {", idErr, replay, idErr)
+		g.in()
+		g.p("panic(%v)", idErr)
+		g.out()
+		g.p("}")
+	}
+	if len(rets) > 0 {
+		g.p("return %v", strings.Join(returns, ", "))
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateRecordingInterface(t *testing.T) {
+	g := generator{packageMap: map[string]string{"context": "context", "io": "io", recordImportPath: "record"}}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Delete",
+		In:   []*model.Parameter{keyParam},
+	})
+
+	if err := generateRecordingInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewRecordingStoreImpl(ctrl Store, recorder *record.Recorder) *RecordingStoreImpl {\n\tif recorder == nil {\n\t\trecorder = record.NewRecorder(io.Discard, nil)\n\t}",
+		"ret, ret_2 := t.delegate.Get(ctx, key)\n\tt.recorder.Record(\"Store.Get\", []any{key}, []any{ret}, ret_2)\n\treturn ret, ret_2",
+		"ret := t.delegate.Len()\n\tt.recorder.Record(\"Store.Len\", []any{}, []any{ret}, nil)",
+		"t.delegate.Delete(key)\n\tt.recorder.Record(\"Store.Delete\", []any{key}, []any{}, nil)",
+		"func NewReplayStore(replayer *record.Replayer) *ReplayStore {",
+		"func (t *ReplayStore) Get(ctx context.Context, key string) (string, error) {\n" +
+			"\tvar ret string\n" +
+			"\terr := t.replayer.Replay(\"Store.Get\", []any{key}, &ret)\n" +
+			"\treturn ret, err\n}",
+		"var ret int\n\tif err := t.replayer.Replay(\"Store.Len\", []any{}, &ret); err != nil {\n\t\tpanic(err)\n\t}\n\treturn ret",
+		"if err := t.replayer.Replay(\"Store.Delete\", []any{key}); err != nil {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+// This file contains the retry decorator, which retries the failed calls
+// according to a retry.Policy.
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const retryImportPath = "github.com/pableeee/implgen/retry"
+
+// retryImports are the packages referenced by the retry decorator.
+var retryImports = map[string]string{
+	"context":       "",
+	retryImportPath: "",
+}
+
+// nonIdempotentDirective marks the methods that are never retried.
+const nonIdempotentDirective = "nonidempotent"
+
+// retryOptions configures the retry decorator.
+type retryOptions struct {
+	nonIdempotent map[string]bool // selectors of the methods that are never retried
+}
+
+// retries reports whether the calls of the method m of the interface intf
+// are retried.
+func (o retryOptions) retries(intf string, m *model.Method) bool {
+	return errorResultOf(m).index >= 0 &&
+		!selects(o.nonIdempotent, intf, m.Name) &&
+		!hasDirective(m, nonIdempotentDirective)
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) retryingName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Retrying" + typeName + "Impl"
+}
+
+func generateRetryingInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.retryingName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	policyType := g.qualify(retryImportPath, "Policy")
+
+	g.p("")
+	g.p("// %v is a retry decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("policy   %v", policyType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	g.p("// New%v creates a new retry decorator instance, retrying the", mockType)
+	g.p("// failed calls according to policy. Defaults to %v{} if policy is nil.", g.qualify(retryImportPath, "Backoff"))
+	g.p("func New%v%v(ctrl %v, policy %v) *%v%v {", mockType, longTp, intfType, policyType, mockType, shortTp)
+	g.in()
+	g.p("if policy == nil {")
+	g.in()
+	g.p("policy = %v{}", g.qualify(retryImportPath, "Backoff"))
+	g.out()
+	g.p("}")
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate: ctrl,")
+	g.p("policy:   policy,")
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateRetryingMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateRetryingMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v retrying base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if g.retry.retries(intf.Name, m) {
+		generateRetryingCall(g, m, argNames, rets, ia, idRecv, callArgs)
+	} else if len(rets) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	}
+	g.out()
+	g.p("}")
+}
+
+// generateRetryingCall writes the statements calling the delegate through
+// retry.Do and returning the results of its last attempt.
+func generateRetryingCall(g *generator, m *model.Method, argNames, rets []string, ia identifierAllocator, idRecv, callArgs string) {
+	ctx := g.qualify("context", "Background") + "()"
+	if i := contextArgIndex(m); i >= 0 {
+		ctx = argNames[i]
+	}
+	returns := make([]string, len(rets))
+	for i, ret := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+		g.p("var %v %v", returns[i], ret)
+	}
+	g.p("_ = %v(%v, %v.policy, func() error {", g.qualify(retryImportPath, "Do"), ctx, idRecv)
+	g.in()
+	g.p("%v = %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+	er := errorResultOf(m)
+	if !er.dynamic {
+		g.p("return %v", returns[er.index])
+	} else {
+		cond, err := er.errorCheck(returns, ia)
+		g.p("if %v {", cond)
+		g.in()
+		g.p("return %v", err)
+		g.out()
+		g.p("}")
+		g.p("return nil")
+	}
+	g.out()
+	g.p("})")
+	g.p("return %v", strings.Join(returns, ", "))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateRetryingInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", retryImportPath: "retry"},
+		retry:      retryOptions{nonIdempotent: parseSelectors("Store.Put")},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, {Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{ctxParam, {Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name:       "Delete",
+		In:         []*model.Parameter{{Name: "key", Type: model.PredeclaredType("string")}},
+		Out:        []*model.Parameter{{Type: model.PredeclaredType("error")}},
+		Directives: []string{"nonidempotent"},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Flush",
+		Out:  []*model.Parameter{{Type: &model.PointerType{Type: &model.NamedType{Package: "somepackage", Type: "FlushError"}}}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateRetryingInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewRetryingStoreImpl(ctrl Store, policy retry.Policy) *RetryingStoreImpl {",
+		"_ = retry.Do(ctx, t.policy, func() error {",
+		"ret, ret_2 = t.delegate.Get(ctx, key)",
+		"return ret_2",
+		"return t.delegate.Put(ctx, key)",
+		"return t.delegate.Delete(key)",
+		"_ = retry.Do(context.Background(), t.policy, func() error {",
+		"if err, ok := any(ret).(error); ok && ret != nil {",
+		"t.delegate.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
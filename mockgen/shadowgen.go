@@ -0,0 +1,164 @@
+package main
+
+// This file contains the shadow decorator, which serves the calls from a
+// primary implementation of an interface and repeats them asynchronously on
+// a shadow one, comparing their results with the shadow package.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const shadowImportPath = "github.com/pableeee/implgen/shadow"
+
+// shadowImports are the packages referenced by the shadow decorator.
+var shadowImports = map[string]string{
+	"context":        "",
+	shadowImportPath: "",
+}
+
+// shadowOptions configures the shadow decorator.
+type shadowOptions struct {
+	methods map[string]bool // selectors of the methods whose calls are repeated on the shadow
+}
+
+// shadows reports whether the calls of the method m of the interface intf
+// are repeated on the shadow. The non-idempotent methods are only repeated if
+// they are selected by their Interface.Method name.
+func (o shadowOptions) shadows(intf string, m *model.Method) bool {
+	if hasDirective(m, nonIdempotentDirective) {
+		return o.methods[intf+"."+m.Name]
+	}
+	return selects(o.methods, intf, m.Name)
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) shadowedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Shadowed" + typeName + "Impl"
+}
+
+func generateShadowedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.shadowedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	runnerType := "*" + g.qualify(shadowImportPath, "Runner")
+
+	g.p("")
+	g.p("// %v is a shadow decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("primary  %v", intfType)
+	g.p("shadowed %v", intfType)
+	g.p("runner   %v", runnerType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	g.p("// New%v creates a new shadow decorator instance, serving the calls", mockType)
+	g.p("// from primary and repeating them on shadowed with runner. Defaults to")
+	g.p("// %v(%v{}) if runner is nil.", g.qualify(shadowImportPath, "New"), g.qualify(shadowImportPath, "Settings"))
+	g.p("func New%v%v(primary, shadowed %v, runner %v) *%v%v {", mockType, longTp, intfType, runnerType, mockType, shortTp)
+	g.in()
+	g.p("if runner == nil {")
+	g.in()
+	g.p("runner = %v(%v{})", g.qualify(shadowImportPath, "New"), g.qualify(shadowImportPath, "Settings"))
+	g.out()
+	g.p("}")
+	g.p("return &%v%v{primary: primary, shadowed: shadowed, runner: runner}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	shadowed := false
+	for _, m := range intf.Methods {
+		shadowed = shadowed || g.shadow.shadows(intf.Name, m)
+		g.p("")
+		generateShadowedMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	if !shadowed {
+		log.Printf("Warning: no method of %v is selected by -shadow_methods, and its calls are not repeated on the shadow", intf.Name)
+	}
+	return nil
+}
+
+func generateShadowedMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v shadow base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if !g.shadow.shadows(intf.Name, m) {
+		if len(rets) == 0 {
+			g.p("%v.primary.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.primary.%v(%v)", idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+		return
+	}
+
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+	retList := strings.Join(returns, ", ")
+
+	// The shadow call is passed the arguments of the primary call, but its
+	// context, which the runner derives from the context of the primary call.
+	contextType := g.qualify("context", "Context")
+	ctx := g.qualify("context", "Background") + "()"
+	fn := fmt.Sprintf("func(%v) []any", contextType)
+	ctxIndex := contextArgIndex(m)
+	if ctxIndex >= 0 {
+		ctx = argNames[ctxIndex]
+		fn = fmt.Sprintf("func(%v %v) []any", argNames[ctxIndex], contextType)
+	}
+	var args []string
+	for i, name := range argNames {
+		if i != ctxIndex {
+			args = append(args, name)
+		}
+	}
+	results := "nil"
+	if len(rets) > 0 {
+		results = fmt.Sprintf("[]any{%v}", retList)
+	}
+
+	if len(rets) == 0 {
+		g.p("%v.primary.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("%v := %v.primary.%v(%v)", retList, idRecv, m.Name, callArgs)
+	}
+	g.p("%v.runner.Go(%v, %q, []any{%v}, %v, %v {", idRecv, ctx, intf.Name+"."+m.Name, strings.Join(args, ", "), results, fn)
+	g.in()
+	if len(rets) == 0 {
+		g.p("%v.shadowed.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("%v := %v.shadowed.%v(%v)", retList, idRecv, m.Name, callArgs)
+	}
+	g.p("return %v", results)
+	g.out()
+	g.p("})")
+	if len(rets) > 0 {
+		g.p("return %v", retList)
+	}
+	g.out()
+	g.p("}")
+}
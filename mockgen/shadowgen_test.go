@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateShadowedInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", shadowImportPath: "shadow"},
+		shadow:     shadowOptions{methods: parseSelectors("Store.Get,Store.Len,Store.Close")},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+
+	if err := generateShadowedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewShadowedStoreImpl(primary, shadowed Store, runner *shadow.Runner) *ShadowedStoreImpl {\n\tif runner == nil {\n\t\trunner = shadow.New(shadow.Settings{})\n\t}",
+		"ret, ret_2 := t.primary.Get(ctx, key)\n" +
+			"\tt.runner.Go(ctx, \"Store.Get\", []any{key}, []any{ret, ret_2}, func(ctx context.Context) []any {\n" +
+			"\t\tret, ret_2 := t.shadowed.Get(ctx, key)\n" +
+			"\t\treturn []any{ret, ret_2}\n" +
+			"\t})\n" +
+			"\treturn ret, ret_2",
+		"t.runner.Go(context.Background(), \"Store.Len\", []any{}, []any{ret}, func(context.Context) []any {",
+		"t.primary.Close()\n\tt.runner.Go(context.Background(), \"Store.Close\", []any{}, nil, func(context.Context) []any {\n\t\tt.shadowed.Close()\n\t\treturn nil",
+		"return t.primary.Put(ctx, key)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "t.shadowed.Put") {
+		t.Errorf("generated code repeats Put, which is not selected:\n%s", out)
+	}
+}
+
+func TestShadowOptions_Shadows(t *testing.T) {
+	get := &model.Method{Name: "Get"}
+	charge := &model.Method{Name: "Charge", Directives: []string{"nonidempotent"}}
+	for _, tc := range []struct {
+		methods string
+		m       *model.Method
+		want    bool
+	}{
+		{methods: "", m: get, want: false},
+		{methods: "*", m: get, want: true},
+		{methods: "Store", m: get, want: true},
+		{methods: "*", m: charge, want: false},
+		{methods: "Store", m: charge, want: false},
+		{methods: "Store.Charge", m: charge, want: true},
+	} {
+		o := shadowOptions{methods: parseSelectors(tc.methods)}
+		if got := o.shadows("Store", tc.m); got != tc.want {
+			t.Errorf("shadows(%q, %v) = %v, want %v", tc.methods, tc.m.Name, got, tc.want)
+		}
+	}
+}
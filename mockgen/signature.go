@@ -0,0 +1,161 @@
+package main
+
+// This file contains the detection of the method arguments and results that
+// decorators handle specially, such as contexts and errors.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// isContextType reports whether t is context.Context, whatever the name under
+// which the context package is imported.
+func isContextType(t model.Type) bool {
+	nt, ok := t.(*model.NamedType)
+	return ok && nt.Package == "context" && nt.Type == "Context"
+}
+
+// contextArgIndex returns the index of the context.Context argument of m, or
+// -1 if there is none.
+func contextArgIndex(m *model.Method) int {
+	for i, p := range m.In {
+		if isContextType(p.Type) {
+			return i
+		}
+	}
+	return -1
+}
+
+// errorResult is the result through which a method signals its failure.
+type errorResult struct {
+	index int // index of the result, -1 if the method cannot fail
+
+	// dynamic is set when the result is not of type error, and so fails
+	// when its dynamic value implements error.
+	dynamic bool
+
+	// pointer is set when the result is a pointer, which does not fail
+	// when nil.
+	pointer bool
+}
+
+// isErrorTypeName reports whether t is a named type that, by the naming
+// convention of the error types, implements error, as *fs.PathError does. The
+// model does not hold the methods of the named types.
+func isErrorTypeName(t *model.NamedType) bool {
+	return strings.HasSuffix(t.Type, "Error")
+}
+
+// errorResultOf returns the result of m that signals its failure. It is the
+// last result of type error or, if there is none, the last result if it is of
+// an error type, named as such, or a pointer to one.
+func errorResultOf(m *model.Method) errorResult {
+	for i := len(m.Out) - 1; i >= 0; i-- {
+		if m.Out[i].Type == model.PredeclaredType("error") {
+			return errorResult{index: i}
+		}
+	}
+	if len(m.Out) == 0 {
+		return errorResult{index: -1}
+	}
+	switch t := m.Out[len(m.Out)-1].Type.(type) {
+	case *model.NamedType:
+		if isErrorTypeName(t) {
+			return errorResult{index: len(m.Out) - 1, dynamic: true}
+		}
+	case *model.PointerType:
+		if nt, ok := t.Type.(*model.NamedType); ok && isErrorTypeName(nt) {
+			return errorResult{index: len(m.Out) - 1, dynamic: true, pointer: true}
+		}
+	}
+	return errorResult{index: -1}
+}
+
+// errorCheck returns the condition of an if statement testing whether the
+// results held by rets signal a failure, and the expression of the error
+// within the statement.
+func (er errorResult) errorCheck(rets []string, ia identifierAllocator) (cond, err string) {
+	ret := rets[er.index]
+	if !er.dynamic {
+		return ret + " != nil", ret
+	}
+	err = ia.allocateIdentifier("err")
+	idOk := ia.allocateIdentifier("ok")
+	cond = fmt.Sprintf("%v, %v := any(%v).(error); %v", err, idOk, ret, idOk)
+	if er.pointer {
+		cond += fmt.Sprintf(" && %v != nil", ret)
+	}
+	return cond, err
+}
+
+// makeRetString returns the types of the results of m and the result list
+// of its signature, including its leading space.
+func (g *generator) makeRetString(m *model.Method, pkgOverride string) ([]string, string) {
+	rets := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		rets[i] = p.Type.String(g.packageMap, pkgOverride)
+	}
+	retString := strings.Join(rets, ", ")
+	if len(rets) > 1 {
+		retString = "(" + retString + ")"
+	}
+	if retString != "" {
+		retString = " " + retString
+	}
+	return rets, retString
+}
+
+// makeCallArgs returns the arguments passing argNames on to m, spreading the
+// variadic one.
+func makeCallArgs(m *model.Method, argNames []string) string {
+	if m.Variadic == nil || len(argNames) == 0 {
+		return strings.Join(argNames, ", ")
+	}
+	return strings.Join(argNames, ", ") + "..."
+}
+
+// hasDirective reports whether m is annotated with the given directive, as in
+// //implgen:<directive>.
+func hasDirective(m *model.Method, directive string) bool {
+	_, ok := directiveArgs(m, directive)
+	return ok
+}
+
+// directiveArgs returns the space-separated arguments of the given directive
+// of m, as in //implgen:<directive> <args>, and whether m has the directive.
+func directiveArgs(m *model.Method, directive string) ([]string, bool) {
+	var args []string
+	found := false
+	for _, d := range m.Directives {
+		if fields := strings.Fields(d); len(fields) > 0 && fields[0] == directive {
+			args = append(args, fields[1:]...)
+			found = true
+		}
+	}
+	return args, found
+}
+
+// returnsError reports whether m has a result of type error, through which a
+// decorator may return its own errors.
+func returnsError(m *model.Method) bool {
+	er := errorResultOf(m)
+	return er.index >= 0 && !er.dynamic
+}
+
+// generateErrorReturn writes the statements returning err in the error
+// result of m and the zero values of its other results, declared with the
+// identifiers of returns.
+func (g *generator) generateErrorReturn(m *model.Method, rets, returns []string, err string) {
+	values := make([]string, len(rets))
+	for i, ret := range rets {
+		if i == errorResultOf(m).index {
+			values[i] = err
+			continue
+		}
+		values[i] = returns[i]
+		g.p("var %v %v", returns[i], ret)
+	}
+	g.p("return %v", strings.Join(values, ", "))
+}
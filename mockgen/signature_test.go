@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestContextArgIndex(t *testing.T) {
+	ctx := &model.Parameter{Type: &model.NamedType{Package: "context", Type: "Context"}}
+	str := &model.Parameter{Type: model.PredeclaredType("string")}
+	other := &model.Parameter{Type: &model.NamedType{Package: "example.com/context", Type: "Context"}}
+	for _, tc := range []struct {
+		in   []*model.Parameter
+		want int
+	}{
+		{in: nil, want: -1},
+		{in: []*model.Parameter{ctx, str}, want: 0},
+		{in: []*model.Parameter{str, ctx}, want: 1},
+		{in: []*model.Parameter{other, str}, want: -1},
+	} {
+		if got := contextArgIndex(&model.Method{In: tc.in}); got != tc.want {
+			t.Errorf("contextArgIndex(%v) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestErrorResultOf(t *testing.T) {
+	errT := &model.Parameter{Type: model.PredeclaredType("error")}
+	intT := &model.Parameter{Type: model.PredeclaredType("int")}
+	named := &model.Parameter{Type: &model.NamedType{Package: "example.com/errs", Type: "Error"}}
+	pointer := &model.Parameter{Type: &model.PointerType{Type: &model.NamedType{Package: "example.com/errs", Type: "Error"}}}
+	status := &model.Parameter{Type: &model.NamedType{Package: "example.com/errs", Type: "MyStatus"}}
+	statusPointer := &model.Parameter{Type: &model.PointerType{Type: &model.NamedType{Package: "example.com/errs", Type: "MyStatus"}}}
+	pathError := &model.Parameter{Type: &model.PointerType{Type: &model.NamedType{Package: "io/fs", Type: "PathError"}}}
+	ctx := &model.Parameter{Type: &model.NamedType{Package: "context", Type: "Context"}}
+	for _, tc := range []struct {
+		name string
+		out  []*model.Parameter
+		want errorResult
+	}{
+		{name: "none", out: nil, want: errorResult{index: -1}},
+		{name: "int", out: []*model.Parameter{intT}, want: errorResult{index: -1}},
+		{name: "error", out: []*model.Parameter{intT, errT}, want: errorResult{index: 1}},
+		{name: "leading error", out: []*model.Parameter{errT, named}, want: errorResult{index: 0}},
+		{name: "named", out: []*model.Parameter{intT, named}, want: errorResult{index: 1, dynamic: true}},
+		{name: "pointer", out: []*model.Parameter{pointer}, want: errorResult{index: 0, dynamic: true, pointer: true}},
+		{name: "named not last", out: []*model.Parameter{named, intT}, want: errorResult{index: -1}},
+		{name: "path error", out: []*model.Parameter{intT, pathError}, want: errorResult{index: 1, dynamic: true, pointer: true}},
+		// Named types that are not named as errors are not failures.
+		{name: "status", out: []*model.Parameter{intT, status}, want: errorResult{index: -1}},
+		{name: "status pointer", out: []*model.Parameter{statusPointer}, want: errorResult{index: -1}},
+		{name: "context", out: []*model.Parameter{ctx}, want: errorResult{index: -1}},
+	} {
+		if got := errorResultOf(&model.Method{Out: tc.out}); got != tc.want {
+			t.Errorf("%s: errorResultOf() = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestErrorResultCheck(t *testing.T) {
+	rets := []string{"ret", "ret_2"}
+
+	cond, err := errorResult{index: 1}.errorCheck(rets, newIdentifierAllocator(nil))
+	if want := "ret_2 != nil"; cond != want || err != "ret_2" {
+		t.Errorf("errorCheck() = %q, %q, want %q, %q", cond, err, want, "ret_2")
+	}
+
+	cond, err = errorResult{index: 1, dynamic: true, pointer: true}.errorCheck(rets, newIdentifierAllocator([]string{"err"}))
+	if want := "err_2, ok := any(ret_2).(error); ok && ret_2 != nil"; cond != want || err != "err_2" {
+		t.Errorf("errorCheck() = %q, %q, want %q, %q", cond, err, want, "err_2")
+	}
+}
+
+func TestDirectiveArgs(t *testing.T) {
+	m := &model.Method{Directives: []string{"nonidempotent", "invalidates Get", "invalidates List  Scan"}}
+
+	if !hasDirective(m, "nonidempotent") || hasDirective(m, "cache") {
+		t.Errorf("hasDirective() does not match the directives %q", m.Directives)
+	}
+	args, ok := directiveArgs(m, "invalidates")
+	if want := []string{"Get", "List", "Scan"}; !ok || !reflect.DeepEqual(args, want) {
+		t.Errorf("directiveArgs() = %q, %v, want %q, true", args, ok, want)
+	}
+}
+
+func TestIsInterface(t *testing.T) {
+	g := generator{}
+	for _, tc := range []struct {
+		typ  model.Type
+		want bool
+	}{
+		{model.PredeclaredType("any"), true},
+		{model.PredeclaredType("error"), true},
+		{&model.NamedType{Package: "fmt", Type: "Stringer"}, true},
+		{model.PredeclaredType("string"), false},
+		{&model.NamedType{Package: "time", Type: "Time"}, false},
+		{&model.PointerType{Type: model.PredeclaredType("error")}, false},
+	} {
+		if got := g.isInterface(tc.typ); got != tc.want {
+			t.Errorf("isInterface(%v) = %v, want %v", tc.typ.String(nil, ""), got, tc.want)
+		}
+	}
+}
+
+func TestIsComparable(t *testing.T) {
+	g := generator{}
+	for _, tc := range []struct {
+		typ  model.Type
+		want bool
+	}{
+		{model.PredeclaredType("string"), true},
+		{&model.NamedType{Package: "time", Type: "Time"}, true},
+		{&model.PointerType{Type: model.PredeclaredType("int")}, true},
+		{&model.ArrayType{Len: 2, Type: model.PredeclaredType("int")}, true},
+		{&model.ArrayType{Len: -1, Type: model.PredeclaredType("int")}, false},
+		{&model.ArrayType{Len: 2, Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("int")}}, false},
+		{&model.MapType{Key: model.PredeclaredType("string"), Value: model.PredeclaredType("int")}, false},
+		{&model.FuncType{}, false},
+		// The values of interface types may not be comparable.
+		{model.PredeclaredType("any"), false},
+		{model.PredeclaredType("error"), false},
+		{&model.NamedType{Package: "fmt", Type: "Stringer"}, false},
+		{&model.ArrayType{Len: 2, Type: model.PredeclaredType("error")}, false},
+		// Named types are resolved.
+		{&model.NamedType{Package: "net", Type: "IPNet"}, false},
+		{&model.NamedType{Package: "sync/atomic", Type: "Pointer", TypeParams: &model.TypeParametersType{TypeParameters: []model.Type{model.PredeclaredType("int")}}}, true},
+		{&model.NamedType{Package: "sync/atomic", Type: "Pointer", TypeParams: &model.TypeParametersType{TypeParameters: []model.Type{&model.MapType{}}}}, false},
+		// Types that cannot be resolved and type parameters are assumed to be.
+		{&model.NamedType{Package: "example.com/unknown", Type: "Key"}, true},
+		{model.PredeclaredType("T"), true},
+	} {
+		if got := g.isComparable(tc.typ); got != tc.want {
+			t.Errorf("isComparable(%v) = %v, want %v", tc.typ.String(nil, ""), got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+// This file contains the singleflight decorator, which coalesces the
+// concurrent calls of a method with equal arguments into a single call of
+// the delegate, with the singleflight package.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const singleflightImportPath = "github.com/pableeee/implgen/singleflight"
+
+// singleflightImports are the packages referenced by the singleflight
+// decorator.
+var singleflightImports = map[string]string{
+	"context":              "",
+	singleflightImportPath: "",
+}
+
+// singleflightOptions configures the singleflight decorator.
+type singleflightOptions struct {
+	methods map[string]bool // selectors of the methods whose calls are coalesced
+}
+
+// coalesces reports whether the concurrent calls of the method m of the
+// interface intf are coalesced. The non-idempotent methods are only coalesced
+// if they are selected by their Interface.Method name.
+func (o singleflightOptions) coalesces(intf string, m *model.Method) bool {
+	if hasDirective(m, nonIdempotentDirective) {
+		return o.methods[intf+"."+m.Name]
+	}
+	return selects(o.methods, intf, m.Name)
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) coalescedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Coalesced" + typeName + "Impl"
+}
+
+func generateCoalescedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.coalescedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+
+	// Methods without results are called for their side effects, and are
+	// never coalesced. The methods selected by name must have arguments that
+	// can key their calls, the others are only coalesced when they do.
+	sort.Sort(byMethodName(intf.Methods))
+	coalesced := make(map[string]*callKey)
+	selected := false
+	for _, m := range intf.Methods {
+		if !g.singleflight.coalesces(intf.Name, m) {
+			continue
+		}
+		selected = true
+		if len(m.Out) == 0 {
+			continue
+		}
+		ck, err := g.newCallKey(intf, m, unexported(mockType))
+		if err != nil {
+			if g.singleflight.methods[intf.Name+"."+m.Name] {
+				return err
+			}
+			log.Printf("Warning: %v; its calls are not coalesced", err)
+			continue
+		}
+		coalesced[m.Name] = ck
+	}
+	if !selected {
+		log.Printf("Warning: no method of %v is selected by -singleflight_methods, and its calls are not coalesced", intf.Name)
+	}
+
+	g.p("")
+	g.p("// %v is a singleflight decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("group    %v", g.qualify(singleflightImportPath, "Group"))
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	for _, m := range intf.Methods {
+		if ck := coalesced[m.Name]; ck != nil {
+			g.generateCallKeyTypes(m, ck, longTp, outputPackagePath)
+		}
+	}
+
+	g.p("// New%v creates a new singleflight decorator instance.", mockType)
+	g.p("func New%v%v(ctrl %v) *%v%v {", mockType, longTp, intfType, mockType, shortTp)
+	g.in()
+	g.p("return &%v%v{delegate: ctrl}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateCoalescedMethod(g, mockType, m, coalesced[m.Name], outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateCoalescedMethod(g *generator, mockType string, m *model.Method, ck *callKey, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v singleflight base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if ck == nil {
+		if len(rets) == 0 {
+			g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+		return
+	}
+
+	idValue := ia.allocateIdentifier("v")
+	idErr := "_"
+	if returnsError(m) {
+		idErr = ia.allocateIdentifier("err")
+	}
+	idRes := ia.allocateIdentifier("r")
+	returns := make([]string, len(rets))
+	for i := range rets {
+		returns[i] = ia.allocateIdentifier("ret")
+	}
+
+	// The callers stop waiting when their context is done, which they can
+	// only report through a result of type error.
+	contextType := g.qualify("context", "Context")
+	ctx := g.qualify("context", "Background") + "()"
+	fn := fmt.Sprintf("func(%v) any", contextType)
+	if i := contextArgIndex(m); i >= 0 {
+		ctx = argNames[i]
+		if !returnsError(m) {
+			ctx = fmt.Sprintf("%v(%v)", g.qualify(singleflightImportPath, "WithoutCancel"), ctx)
+		}
+		fn = fmt.Sprintf("func(%v %v) any", argNames[i], contextType)
+	}
+	g.p("%v, %v := %v.group.Do(%v, %v, %v {", idValue, idErr, idRecv, ctx, ck.key(argNames, shortTp), fn)
+	g.in()
+	g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+	g.p("return %v", ck.results(returns, shortTp))
+	g.out()
+	g.p("})")
+	if returnsError(m) {
+		g.p("if %v != nil {", idErr)
+		g.in()
+		g.generateErrorReturn(m, rets, returns, idErr)
+		g.out()
+		g.p("}")
+	}
+	g.p("%v := %v.(%v%v)", idRes, idValue, ck.resType, shortTp)
+	g.p("return %v", strings.Join(ck.resultFields(idRes, len(rets)), ", "))
+	g.out()
+	g.p("}")
+}
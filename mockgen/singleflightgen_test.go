@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateCoalescedInterface(t *testing.T) {
+	g := generator{
+		packageMap:   map[string]string{"context": "context", singleflightImportPath: "singleflight"},
+		singleflight: singleflightOptions{methods: parseSelectors("*")},
+	}
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Count",
+		In:   []*model.Parameter{ctxParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In:   []*model.Parameter{keyParam, {Name: "value", Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("byte")}}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateCoalescedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"group    singleflight.Group",
+		"type coalescedStoreImplGetKey struct {\n\tkey string\n}",
+		"v, err := t.group.Do(ctx, coalescedStoreImplGetKey{key: key}, func(ctx context.Context) any {\n\t\tret, ret_2 := t.delegate.Get(ctx, key)",
+		"return coalescedStoreImplGetResults{r0: ret, r1: ret_2}",
+		"if err != nil {\n\t\tvar ret string\n\t\treturn ret, err\n\t}",
+		"r := v.(coalescedStoreImplGetResults)\n\treturn r.r0, r.r1",
+		// Without a result of type error, the callers wait for the call.
+		"v, _ := t.group.Do(singleflight.WithoutCancel(ctx), coalescedStoreImplCountKey{}, func(ctx context.Context) any {",
+		"v, _ := t.group.Do(context.Background(), coalescedStoreImplLenKey{}, func(context.Context) any {",
+		"return t.delegate.Put(key, value)",
+		"t.delegate.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "coalescedStoreImplPutKey") {
+		t.Errorf("generated code coalesces Put, whose arguments are not comparable:\n%s", out)
+	}
+}
+
+func TestGenerateCoalescedInterface_Errors(t *testing.T) {
+	g := generator{singleflight: singleflightOptions{methods: parseSelectors("Store.GetMany")}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "GetMany",
+		In:   []*model.Parameter{{Name: "keys", Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("string")}}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+	want := `Store.GetMany: argument "keys" of type []string is not comparable`
+	if err := generateCoalescedInterface(&g, intf, "somepackage"); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("generateCoalescedInterface() = %v, want an error containing %q", err, want)
+	}
+}
+
+func TestSingleflightOptions_Coalesces(t *testing.T) {
+	get := &model.Method{Name: "Get"}
+	charge := &model.Method{Name: "Charge", Directives: []string{"nonidempotent"}}
+	for _, tc := range []struct {
+		methods string
+		m       *model.Method
+		want    bool
+	}{
+		{methods: "", m: get, want: false},
+		{methods: "*", m: get, want: true},
+		{methods: "Store", m: get, want: true},
+		{methods: "*", m: charge, want: false},
+		{methods: "Store", m: charge, want: false},
+		{methods: "Store.Charge", m: charge, want: true},
+	} {
+		o := singleflightOptions{methods: parseSelectors(tc.methods)}
+		if got := o.coalesces("Store", tc.m); got != tc.want {
+			t.Errorf("coalesces(%q, %v) = %v, want %v", tc.methods, tc.m.Name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+// This file contains the stub, whose methods call the functions set in its
+// fields, as a lightweight alternative to the mocks.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// stubImports are the packages referenced by the stub.
+var stubImports = map[string]string{
+	"sync": "",
+}
+
+// stubOptions configures the stub.
+type stubOptions struct {
+	panics bool // whether the methods whose function is not set panic, rather than return zero values
+	record bool // whether the calls are recorded
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) stubName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Stub" + typeName
+}
+
+func generateStubInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.stubName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+
+	// The fields and the methods of the stub are named after the methods of
+	// the interface, and must not collide with them.
+	sort.Sort(byMethodName(intf.Methods))
+	methods := make(map[string]bool)
+	for _, m := range intf.Methods {
+		methods[m.Name] = true
+	}
+	for _, m := range intf.Methods {
+		names := []string{m.Name + "Func"}
+		if g.stub.record {
+			names = append(names, m.Name+"Calls", m.Name+"CallCount")
+		}
+		for _, name := range names {
+			if methods[name] {
+				return fmt.Errorf("%v: %v of %v collides with the method %v", mockType, name, m.Name, name)
+			}
+		}
+	}
+
+	g.p("")
+	g.p("// %v is a stub of %v interface, whose methods call the functions", mockType, intf.Name)
+	g.p("// of its fields.")
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	for _, m := range intf.Methods {
+		argNames := g.getArgNames(m, true)
+		argTypes := g.getArgTypes(m, outputPackagePath, true)
+		_, retString := g.makeRetString(m, outputPackagePath)
+		g.p("%vFunc func(%v)%v", m.Name, makeArgString(argNames, argTypes), retString)
+	}
+	if g.stub.record {
+		g.p("")
+		g.p("mu %v", g.qualify("sync", "Mutex"))
+		for _, m := range intf.Methods {
+			g.p("calls%v []%v%v", m.Name, mockType+m.Name+"Call", shortTp)
+		}
+	}
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	if g.stub.record {
+		for _, m := range intf.Methods {
+			generateStubCallType(g, mockType, m, longTp, shortTp, outputPackagePath)
+		}
+	}
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateStubMethod(g, mockType, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+// generateStubCallType writes the type recording the arguments of a call of
+// m, and the methods of the stub returning them.
+func generateStubCallType(g *generator, mockType string, m *model.Method, longTp, shortTp, pkgOverride string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	callType := mockType + m.Name + "Call"
+	field := "calls" + m.Name
+
+	g.p("// %v holds the arguments of a call of %v on a %v.", callType, m.Name, mockType)
+	g.p("type %v%v struct {", callType, longTp)
+	g.in()
+	for i, name := range argNames {
+		g.p("%v %v", exported(name), strings.Replace(argTypes[i], "...", "[]", 1))
+	}
+	g.out()
+	g.p("}")
+	g.p("")
+	g.p("// %vCalls returns the arguments of the calls of %v, in order.", m.Name, m.Name)
+	g.p("func (t *%v%v) %vCalls() []%v%v {", mockType, shortTp, m.Name, callType, shortTp)
+	g.in()
+	g.p("t.mu.Lock()")
+	g.p("defer t.mu.Unlock()")
+	g.p("return append([]%v%v(nil), t.%v...)", callType, shortTp, field)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.p("// %vCallCount returns the number of calls of %v.", m.Name, m.Name)
+	g.p("func (t *%v%v) %vCallCount() int {", mockType, shortTp, m.Name)
+	g.in()
+	g.p("t.mu.Lock()")
+	g.p("defer t.mu.Unlock()")
+	g.p("return len(t.%v)", field)
+	g.out()
+	g.p("}")
+	g.p("")
+}
+
+func generateStubMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	g.p("// %v stub base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if g.stub.record {
+		fields := make([]string, len(argNames))
+		for i, name := range argNames {
+			fields[i] = exported(name) + ": " + name
+		}
+		g.p("%v.mu.Lock()", idRecv)
+		g.p("%v.calls%v = append(%v.calls%v, %v%v{%v})", idRecv, m.Name, idRecv, m.Name, mockType+m.Name+"Call", shortTp, strings.Join(fields, ", "))
+		g.p("%v.mu.Unlock()", idRecv)
+	}
+	g.p("if %v.%vFunc == nil {", idRecv, m.Name)
+	g.in()
+	if g.stub.panics {
+		g.p("panic(%q)", fmt.Sprintf("%v.%v called, but %vFunc is not set", mockType, m.Name, m.Name))
+	} else {
+		var returns []string
+		for _, ret := range rets {
+			idRet := ia.allocateIdentifier("ret")
+			g.p("var %v %v", idRet, ret)
+			returns = append(returns, idRet)
+		}
+		if len(returns) == 0 {
+			g.p("return")
+		} else {
+			g.p("return %v", strings.Join(returns, ", "))
+		}
+	}
+	g.out()
+	g.p("}")
+	if len(rets) == 0 {
+		g.p("%v.%vFunc(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("return %v.%vFunc(%v)", idRecv, m.Name, callArgs)
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func stubTestInterface() *model.Interface {
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("string")},
+		},
+		Out: []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name:     "Delete",
+		In:       []*model.Parameter{{Type: model.PredeclaredType("bool")}},
+		Variadic: &model.Parameter{Name: "keys", Type: model.PredeclaredType("string")},
+	})
+	return intf
+}
+
+func TestGenerateStubInterface(t *testing.T) {
+	g := generator{packageMap: map[string]string{"context": "context"}}
+	if err := generateStubInterface(&g, stubTestInterface(), "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"DeleteFunc func(arg0 bool, keys ...string)",
+		"GetFunc func(ctx context.Context, key string) (string, error)",
+		"if t.GetFunc == nil {\n\t\tvar ret string\n\t\tvar ret_2 error\n\t\treturn ret, ret_2\n\t}\n\treturn t.GetFunc(ctx, key)",
+		"if t.DeleteFunc == nil {\n\t\treturn\n\t}\n\tt.DeleteFunc(arg0, keys...)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Calls") {
+		t.Errorf("generated code records the calls:\n%s", out)
+	}
+}
+
+func TestGenerateStubInterface_PanicRecord(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", "sync": "sync"},
+		stub:       stubOptions{panics: true, record: true},
+	}
+	if err := generateStubInterface(&g, stubTestInterface(), "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"mu sync.Mutex",
+		"callsDelete []StubStoreDeleteCall",
+		"type StubStoreDeleteCall struct {\n\tArg0 bool\n\tKeys []string\n}",
+		"func (t *StubStore) GetCalls() []StubStoreGetCall {",
+		"return append([]StubStoreGetCall(nil), t.callsGet...)",
+		"func (t *StubStore) GetCallCount() int {",
+		"t.callsGet = append(t.callsGet, StubStoreGetCall{Ctx: ctx, Key: key})",
+		`panic("StubStore.Get called, but GetFunc is not set")`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateStubInterface_Collision(t *testing.T) {
+	g := generator{}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{Name: "Get"})
+	intf.AddMethod(&model.Method{Name: "GetFunc"})
+	want := "StubStore: GetFunc of Get collides with the method GetFunc"
+	if err := generateStubInterface(&g, intf, "somepackage"); err == nil || err.Error() != want {
+		t.Errorf("generateStubInterface() = %v, want %q", err, want)
+	}
+}
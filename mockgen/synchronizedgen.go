@@ -0,0 +1,130 @@
+package main
+
+// This file contains the synchronized decorator, which serializes the calls
+// of an implementation that is not safe for concurrent use with a mutex.
+
+import (
+	"log"
+	"sort"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const synchronizedImportPath = "github.com/pableeee/implgen/synchronized"
+
+// synchronizedImports are the packages referenced by the synchronized
+// decorator.
+var synchronizedImports = map[string]string{
+	"sync":                 "",
+	synchronizedImportPath: "",
+}
+
+// readOnlyDirective marks the methods that only take a read lock.
+const readOnlyDirective = "readonly"
+
+// synchronizedOptions configures the synchronized decorator.
+type synchronizedOptions struct {
+	readOnly map[string]bool // selectors of the methods that only take a read lock
+	context  bool            // whether the locks are acquired with the contexts of the calls
+}
+
+// readsOnly reports whether the method m of the interface intf only takes a
+// read lock.
+func (o synchronizedOptions) readsOnly(intf string, m *model.Method) bool {
+	return selects(o.readOnly, intf, m.Name) || hasDirective(m, readOnlyDirective)
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) synchronizedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Synchronized" + typeName
+}
+
+func generateSynchronizedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.synchronizedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	mutexType := g.qualify("sync", "RWMutex")
+	if g.synchronized.context {
+		mutexType = g.qualify(synchronizedImportPath, "RWMutex")
+	}
+
+	g.p("")
+	g.p("// %v is a synchronized decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("mu %v", mutexType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	g.p("// New%v creates a new synchronized decorator instance, serializing the", mockType)
+	g.p("// calls of ctrl.")
+	g.p("func New%v%v(ctrl %v) *%v%v {", mockType, longTp, intfType, mockType, shortTp)
+	g.in()
+	g.p("return &%v%v{delegate: ctrl}", mockType, shortTp)
+	g.out()
+	g.p("}")
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateSynchronizedMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateSynchronizedMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	lock, unlock := "Lock", "Unlock"
+	if g.synchronized.readsOnly(intf.Name, m) {
+		lock, unlock = "RLock", "RUnlock"
+	}
+
+	g.p("// %v synchronized base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	ctxIndex := contextArgIndex(m)
+	withContext := g.synchronized.context && ctxIndex >= 0
+	if withContext && !returnsError(m) {
+		log.Printf("Warning: %v.%v has no result of type error and does not acquire its lock with its context", intf.Name, m.Name)
+		withContext = false
+	}
+	if withContext {
+		// Calls whose context is done before the lock is acquired fail
+		// with its error.
+		idErr := ia.allocateIdentifier("err")
+		returns := make([]string, len(rets))
+		for i := range rets {
+			returns[i] = ia.allocateIdentifier("ret")
+		}
+		g.p("if %v := %v.mu.%vContext(%v); %v != nil {", idErr, idRecv, lock, argNames[ctxIndex], idErr)
+		g.in()
+		g.generateErrorReturn(m, rets, returns, idErr)
+		g.out()
+		g.p("}")
+	} else {
+		g.p("%v.mu.%v()", idRecv, lock)
+	}
+	g.p("defer %v.mu.%v()", idRecv, unlock)
+	if len(rets) == 0 {
+		g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	} else {
+		g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func synchronizedTestInterface() *model.Interface {
+	ctxParam := &model.Parameter{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	keyParam := &model.Parameter{Name: "key", Type: model.PredeclaredType("string")}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, keyParam},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{
+		Name:       "Len",
+		Out:        []*model.Parameter{{Type: model.PredeclaredType("int")}},
+		Directives: []string{"readonly"},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Delete",
+		In:   []*model.Parameter{keyParam},
+	})
+	return intf
+}
+
+func TestGenerateSynchronizedInterface(t *testing.T) {
+	g := generator{
+		packageMap:   map[string]string{"context": "context", "sync": "sync"},
+		synchronized: synchronizedOptions{readOnly: parseSelectors("Store.Get")},
+	}
+	if err := generateSynchronizedInterface(&g, synchronizedTestInterface(), "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"mu sync.RWMutex",
+		"func NewSynchronizedStore(ctrl Store) *SynchronizedStore {",
+		"t.mu.RLock()\n\tdefer t.mu.RUnlock()\n\treturn t.delegate.Get(ctx, key)",
+		"t.mu.RLock()\n\tdefer t.mu.RUnlock()\n\treturn t.delegate.Len()",
+		"t.mu.Lock()\n\tdefer t.mu.Unlock()\n\tt.delegate.Delete(key)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateSynchronizedInterface_Context(t *testing.T) {
+	g := generator{
+		packageMap:   map[string]string{"context": "context", synchronizedImportPath: "synchronized"},
+		synchronized: synchronizedOptions{readOnly: parseSelectors("Store.Get"), context: true},
+	}
+	if err := generateSynchronizedInterface(&g, synchronizedTestInterface(), "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"mu synchronized.RWMutex",
+		"if err := t.mu.RLockContext(ctx); err != nil {\n" +
+			"\t\tvar ret string\n" +
+			"\t\treturn ret, err\n" +
+			"\t}\n" +
+			"\tdefer t.mu.RUnlock()\n" +
+			"\treturn t.delegate.Get(ctx, key)",
+		"t.mu.Lock()\n\tdefer t.mu.Unlock()\n\tt.delegate.Delete(key)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
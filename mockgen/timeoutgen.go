@@ -0,0 +1,161 @@
+package main
+
+// This file contains the timeout decorator, which bounds the duration of the
+// calls of the methods with a context with the timeout package.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const timeoutImportPath = "github.com/pableeee/implgen/timeout"
+
+// timeoutImports are the packages referenced by the timeout decorator.
+var timeoutImports = map[string]string{
+	"time":            "",
+	timeoutImportPath: "",
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) timeoutName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Timeout" + typeName + "Impl"
+}
+
+func generateTimeoutInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.timeoutName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+	durationType := g.qualify("time", "Duration")
+
+	sort.Sort(byMethodName(intf.Methods))
+	var bounded []string
+	for _, m := range intf.Methods {
+		if contextArgIndex(m) >= 0 {
+			bounded = append(bounded, fmt.Sprintf("%q", m.Name))
+		} else {
+			log.Printf("Warning: %v.%v has no context.Context argument and has no timeout", intf.Name, m.Name)
+		}
+	}
+
+	g.p("")
+	g.p("// %v is a timeout decorator of %v interface.", mockType, intf.Name)
+	g.p("type %v%v struct {", mockType, longTp)
+	g.in()
+	g.p("delegate %v", intfType)
+	g.p("timeouts map[string]%v", durationType)
+	g.out()
+	g.p("}")
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "Timeout",
+			param: "d",
+			field: "timeout",
+			typ:   durationType,
+			doc: "sets the timeout of the calls of the methods of a\n" +
+				mockType + ". Defaults to no timeout.",
+		},
+		{
+			name:  "MethodTimeouts",
+			param: "timeouts",
+			field: "methodTimeouts",
+			typ:   "map[string]" + durationType,
+			doc: "sets the timeouts of the calls of the methods\n" +
+				"of a " + mockType + ", by method name, in place of the one set by\n" +
+				"With" + mockType + "Timeout. A zero timeout sets none.",
+		},
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
+
+	g.p("// New%v creates a new timeout decorator instance.", mockType)
+	g.p("func New%v%v(ctrl %v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
+	g.in()
+	g.generateApplyOptions(mockType, opts)
+	g.p("deco := &%v%v{", mockType, shortTp)
+	g.in()
+	g.p("delegate: ctrl,")
+	g.p("timeouts: %v(o.timeout, o.methodTimeouts, %q%v),", g.qualify(timeoutImportPath, "NewMethods"), intf.Name, strings.Join(append([]string{""}, bounded...), ", "))
+	g.out()
+	g.p("}")
+	g.p("return deco")
+	g.out()
+	g.p("}")
+
+	for _, m := range intf.Methods {
+		g.p("")
+		generateTimeoutMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateTimeoutMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+	callArgs := makeCallArgs(m, argNames)
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("t")
+
+	forward := func() {
+		if len(rets) == 0 {
+			g.p("%v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		} else {
+			g.p("return %v.delegate.%v(%v)", idRecv, m.Name, callArgs)
+		}
+	}
+
+	g.p("// %v timeout base method.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	ctxIndex := contextArgIndex(m)
+	if ctxIndex < 0 {
+		forward()
+		g.out()
+		g.p("}")
+		return
+	}
+
+	ctx := argNames[ctxIndex]
+	idTimeout := ia.allocateIdentifier("d")
+	idOk := ia.allocateIdentifier("ok")
+	idCancel := ia.allocateIdentifier("cancel")
+	g.p("%v, %v := %v.timeouts[%q]", idTimeout, idOk, idRecv, m.Name)
+	g.p("if !%v {", idOk)
+	g.in()
+	forward()
+	if len(rets) == 0 {
+		g.p("return")
+	}
+	g.out()
+	g.p("}")
+	g.p("%v, %v := %v(%v, %q, %v)", ctx, idCancel, g.qualify(timeoutImportPath, "WithTimeout"), ctx, intf.Name+"."+m.Name, idTimeout)
+	g.p("defer %v()", idCancel)
+	if returnsError(m) {
+		returns := make([]string, len(rets))
+		for i := range rets {
+			returns[i] = ia.allocateIdentifier("ret")
+		}
+		g.p("%v := %v.delegate.%v(%v)", strings.Join(returns, ", "), idRecv, m.Name, callArgs)
+		er := errorResultOf(m)
+		returns[er.index] = fmt.Sprintf("%v(%v, %v)", g.qualify(timeoutImportPath, "Wrap"), ctx, returns[er.index])
+		g.p("return %v", strings.Join(returns, ", "))
+	} else {
+		// Without a result of type error, the timeouts are not reported.
+		forward()
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateTimeoutInterface(t *testing.T) {
+	g := generator{
+		packageMap: map[string]string{"context": "context", "time": "time", timeoutImportPath: "timeout"},
+	}
+	ctxParam := &model.Parameter{Name: "c", Type: &model.NamedType{Package: "context", Type: "Context"}}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In:   []*model.Parameter{ctxParam, {Name: "key", Type: model.PredeclaredType("string")}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Wait", In: []*model.Parameter{ctxParam}})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateTimeoutInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"func NewTimeoutStoreImpl(ctrl Store, opts ...TimeoutStoreImplOption) *TimeoutStoreImpl {",
+		`timeouts: timeout.NewMethods(o.timeout, o.methodTimeouts, "Store", "Get", "Wait"),`,
+		`d, ok := t.timeouts["Get"]`,
+		`c, cancel := timeout.WithTimeout(c, "Store.Get", d)`,
+		"return ret, timeout.Wrap(c, ret_2)",
+		`c, cancel := timeout.WithTimeout(c, "Store.Wait", d)`,
+		"t.delegate.Wait(c)\n\t\treturn\n",
+		"t.delegate.Close()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
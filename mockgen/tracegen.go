@@ -19,94 +19,269 @@ package main
 // TODO: This does not support embedding package-local interfaces in a separate file.
 
 import (
+	"bytes"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/pableeee/implgen/mockgen/model"
 )
 
-// The name of the mock type to use for the given interface identifier.
-func (g *generator) tracedName(typeName string) string {
-	if mockName, ok := g.mockNames[typeName]; ok {
-		return mockName
+const (
+	otelCodesImportPath = "go.opentelemetry.io/otel/codes"
+	otelTraceImportPath = "go.opentelemetry.io/otel/trace"
+)
+
+// traceImports are the packages referenced by the trace decorator.
+var traceImports = map[string]string{
+	"context":               "",
+	"fmt":                   "",
+	otelImportPath:          "",
+	otelAttributeImportPath: "",
+	otelCodesImportPath:     "",
+	otelTraceImportPath:     "",
+}
+
+// How the trace decorator handles methods without a context.Context argument.
+const (
+	contextFreeNone = "none" // not traced
+	contextFreeRoot = "root" // traced in a new root span
+	contextFreeLink = "link" // traced in a new root span, linked to the spans carried by the arguments
+)
+
+// defaultSpanName is the default template of span names.
+const defaultSpanName = "{{.Interface}}.{{.Method}}"
+
+// spanKinds maps the span kinds to the constants of the trace package.
+var spanKinds = map[string]string{
+	"internal": "SpanKindInternal",
+	"server":   "SpanKindServer",
+	"client":   "SpanKindClient",
+	"producer": "SpanKindProducer",
+	"consumer": "SpanKindConsumer",
+}
+
+// traceOptions configures the trace decorator.
+type traceOptions struct {
+	contextFree string             // contextFreeNone if empty
+	scope       string             // instrumentation scope; the package import path if empty
+	spanName    *template.Template // defaultSpanName if nil
+	spanKind    string             // a key of spanKinds; internal if empty
+}
+
+// spanNameData is the data of the span name template.
+type spanNameData struct {
+	Scope     string // instrumentation scope
+	Interface string // interface name
+	Method    string // method name
+}
+
+// traceScope returns the instrumentation scope of the tracer of intf.
+func (g *generator) traceScope(intf *model.Interface) string {
+	if g.trace.scope != "" {
+		return g.trace.scope
+	}
+	if g.pkgPath != "" {
+		return g.pkgPath
 	}
+	return intf.Name
+}
 
-	return "Traced" + typeName + "Impl"
+// spanName returns the name of the spans of m.
+func (g *generator) spanName(intf *model.Interface, m *model.Method) (string, error) {
+	tmpl := g.trace.spanName
+	if tmpl == nil {
+		tmpl = template.Must(template.New("span_name").Parse(defaultSpanName))
+	}
+	var buf bytes.Buffer
+	data := spanNameData{Scope: g.traceScope(intf), Interface: intf.Name, Method: m.Name}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("span name of %v.%v: %v", intf.Name, m.Name, err)
+	}
+	return buf.String(), nil
 }
 
-func generateTracedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
-	mockType := g.tracedName(intf.Name)
-	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+// spanStartOptions returns the options of the spans started by the
+// decorator, in addition to extra.
+func (g *generator) spanStartOptions(extra ...string) string {
+	var opts []string
+	if kind := g.trace.spanKind; kind != "" && kind != "internal" {
+		opts = append(opts, fmt.Sprintf("%v(%v)", g.qualify(otelTraceImportPath, "WithSpanKind"), g.qualify(otelTraceImportPath, spanKinds[kind])))
+	}
+	opts = append(opts, extra...)
+	if len(opts) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(opts, ", ")
+}
 
-	g.p("")
-	g.p("// %v is a tracing decorator of %v interface.", mockType, intf.Name)
-	g.p("type Traced%v interface {", intf.Name)
-	g.in()
+// spanCarriers returns the indexes of the arguments of m that may carry a
+// span. Values of named types carry a span if they implement
+// Context() context.Context, as *http.Request does.
+func spanCarriers(m *model.Method) []int {
+	var carriers []int
+	for i, p := range m.In {
+		if kind := attributeKindOf(p.Type); kind == attrExtractor || kind == attrExtractorPtr {
+			carriers = append(carriers, i)
+		}
+	}
+	return carriers
+}
 
-	for _, m := range intf.Methods {
-		argNames := g.getArgNames(m, true)
-		argTypes := g.getArgTypes(m, outputPackagePath, true)
-		argString := makeArgString(argNames, argTypes)
+// generateSpanLinks writes the statements collecting into links the spans
+// carried by the arguments of m at the indexes carriers.
+func (g *generator) generateSpanLinks(links string, m *model.Method, argNames []string, carriers []int) {
+	g.p("var %v []%v", links, g.qualify(otelTraceImportPath, "Link"))
+	for _, i := range carriers {
+		kind := attributeKindOf(m.In[i].Type)
+		cond := ""
+		if kind == attrExtractorPtr {
+			cond = fmt.Sprintf(" && %v != nil", argNames[i])
+		}
+		g.p("if c, ok := any(%v).(interface{ Context() %v }); ok%v {", argNames[i], g.qualify("context", "Context"), cond)
+		g.in()
+		g.p("if sc := %v(c.Context()); sc.IsValid() {", g.qualify(otelTraceImportPath, "SpanContextFromContext"))
+		g.in()
+		g.p("%v = append(%v, %v{SpanContext: sc})", links, links, g.qualify(otelTraceImportPath, "Link"))
+		g.out()
+		g.p("}")
+		g.out()
+		g.p("}")
+	}
+}
 
-		rets := make([]string, len(m.Out))
-		for i, p := range m.Out {
-			rets[i] = p.Type.String(g.packageMap, outputPackagePath)
+// spanAttributesMethod is the method through which values of named types
+// extract their span attributes.
+const spanAttributesMethod = "SpanAttributes"
+
+// generateSpanAttributes writes the statements recording rps as attributes of
+// the span. Values of named types are recorded if they implement
+// SpanAttributes(key string) []attribute.KeyValue.
+func (g *generator) generateSpanAttributes(span string, rps []recordedParam) {
+	var attrs []string
+	for _, rp := range rps {
+		var fn, expr string
+		switch rp.kind {
+		case attrString:
+			fn, expr = "String", rp.expr
+		case attrBool:
+			fn, expr = "Bool", rp.expr
+		case attrInt:
+			fn, expr = "Int", rp.expr
+		case attrInt64:
+			fn, expr = "Int64", "int64("+rp.expr+")"
+		case attrFloat64:
+			fn, expr = "Float64", "float64("+rp.expr+")"
+		case attrDuration:
+			fn, expr = "String", rp.expr+".String()"
+		default:
+			continue
 		}
-		retString := strings.Join(rets, ", ")
-		if len(rets) > 1 {
-			retString = "(" + retString + ")"
+		attrs = append(attrs, fmt.Sprintf("%v(%q, %v)", g.qualify(otelAttributeImportPath, fn), rp.key, expr))
+	}
+	if len(attrs) > 0 {
+		g.p("%v.SetAttributes(%v)", span, strings.Join(attrs, ", "))
+	}
+
+	for _, rp := range rps {
+		if rp.kind != attrExtractor && rp.kind != attrExtractorPtr {
+			continue
 		}
-		if retString != "" {
-			retString = " " + retString
+		cond := ""
+		if rp.kind == attrExtractorPtr {
+			cond = fmt.Sprintf(" && %v != nil", rp.expr)
 		}
+		g.p("if a, ok := any(%v).(interface{ %v(string) []%v }); ok%v {", rp.expr, spanAttributesMethod, g.qualify(otelAttributeImportPath, "KeyValue"), cond)
+		g.in()
+		g.p("%v.SetAttributes(a.%v(%q)...)", span, spanAttributesMethod, rp.key)
+		g.out()
+		g.p("}")
+	}
+}
 
-		g.p("%s(%s) %s", m.Name, argString, retString)
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) tracedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
 	}
 
-	g.out()
-	g.p("}")
-	g.p("")
+	return "Traced" + typeName + "Impl"
+}
+
+func generateTracedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.tracedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
 
 	g.p("")
 	g.p("// %v is a tracing decorator of %v interface.", mockType, intf.Name)
 	g.p("type %v%v struct {", mockType, longTp)
 	g.in()
-	g.p("delegate Traced%v", intf.Name)
+	g.p("delegate  %v", intfType)
+	g.p("tracer    %v", g.qualify(otelTraceImportPath, "Tracer"))
+	g.p("isFailure func(error) bool")
 	g.out()
 	g.p("}")
 	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	opts := []decoratorOption{
+		{
+			name:  "TracerProvider",
+			param: "tp",
+			field: "tracerProvider",
+			typ:   g.qualify(otelTraceImportPath, "TracerProvider"),
+			def:   g.qualify(otelImportPath, "GetTracerProvider") + "()",
+			doc: "sets the TracerProvider creating the tracer\n" +
+				"of a " + mockType + ". Defaults to the global TracerProvider.",
+		},
+		errorClassifierOption(mockType),
+	}
+	optionType, _ := optionsTypes(mockType)
+	g.generateOptions(mockType, opts)
 
 	g.p("// New%v creates a new trace decorator instance.", mockType)
-	g.p("func New%v%v(ctrl Traced%v) *%v%v {", mockType, longTp, intf.Name, mockType, shortTp)
+	g.p("func New%v%v(ctrl %v, opts ...%v) *%v%v {", mockType, longTp, intfType, optionType, mockType, shortTp)
 	g.in()
-	g.p(`deco := &%v%v{delegate: ctrl}`, mockType, shortTp)
+	g.generateApplyOptions(mockType, opts)
+	g.p(`deco := &%v%v{delegate: ctrl, tracer: o.tracerProvider.Tracer(%q), isFailure: o.isFailure}`, mockType, shortTp, g.traceScope(intf))
 	g.p("return deco")
 	g.out()
 	g.p("}")
 	g.p("")
 
-	generateTracedMethods(g, mockType, intf, outputPackagePath, shortTp)
-
-	return nil
+	return generateTracedMethods(g, mockType, intf, outputPackagePath, shortTp)
 }
-func generateTracedMethods(g *generator, mockType string, intf *model.Interface, pkgOverride, shortTp string) {
+
+func generateTracedMethods(g *generator, mockType string, intf *model.Interface, pkgOverride, shortTp string) error {
 	sort.Sort(byMethodName(intf.Methods))
 	for _, m := range intf.Methods {
 		g.p("")
-		_ = generateTracedMethod(g, mockType, m, pkgOverride, shortTp)
+		if err := generateTracedMethod(g, mockType, intf, m, pkgOverride, shortTp); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // GenerateMockMethod generates a mock method implementation.
 // If non-empty, pkgOverride is the package in which unqualified types reside.
-func generateTracedMethod(g *generator, mockType string, m *model.Method, pkgOverride, shortTp string) error {
+func generateTracedMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) error {
 	argNames := g.getArgNames(m, true)
 	argTypes := g.getArgTypes(m, pkgOverride, true)
 	argString := makeArgString(argNames, argTypes)
 
-	// flag as context method, if the firt argument is a context.
-	isContextMethod := len(argNames) > 0 && argTypes[0] == "context.Context"
+	ctxIndex := contextArgIndex(m)
+	contextFree := g.trace.contextFree
+	if contextFree == "" {
+		contextFree = contextFreeNone
+	}
+	isTraced := ctxIndex >= 0 || contextFree != contextFreeNone
+	if !isTraced {
+		log.Printf("Warning: %v.%v has no context.Context argument and is not traced; see -trace_context_free", intf.Name, m.Name)
+	}
 
 	rets := make([]string, len(m.Out))
 	for i, p := range m.Out {
@@ -128,14 +303,38 @@ func generateTracedMethod(g *generator, mockType string, m *model.Method, pkgOve
 	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
 	g.in()
 
-	if isContextMethod {
-		ctxArg := argNames[0]
-		// We'll input the tracing code, if the method bares a context as its firts param.
-		idTracer := ia.allocateIdentifier("tracer")
-		// TODO: Fix tracer name (without the 'Mock') & constant initialization.
-		g.p(`%v := otel.Tracer("%s")`, idTracer, mockType)
-		g.p("%s, %v := %v.Start(%v, %q)", ctxArg, idSpan, idTracer, ctxArg, m.Name)
-		g.p("defer span.End()")
+	if isTraced {
+		spanName, err := g.spanName(intf, m)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ctxIndex >= 0:
+			ctxArg := argNames[ctxIndex]
+			g.p("%s, %v := %v.tracer.Start(%v, %q%v)", ctxArg, idSpan, idRecv, ctxArg, spanName, g.spanStartOptions())
+		case contextFree == contextFreeLink && len(spanCarriers(m)) > 0:
+			// Methods without arguments carrying spans have no links.
+			idLinks := ia.allocateIdentifier("links")
+			g.generateSpanLinks(idLinks, m, argNames, spanCarriers(m))
+			withLinks := fmt.Sprintf("%v(%v...)", g.qualify(otelTraceImportPath, "WithLinks"), idLinks)
+			g.p("_, %v := %v.tracer.Start(%v(), %q%v)", idSpan, idRecv, g.qualify("context", "Background"), spanName, g.spanStartOptions(withLinks))
+		default:
+			g.p("_, %v := %v.tracer.Start(%v(), %q%v)", idSpan, idRecv, g.qualify("context", "Background"), spanName, g.spanStartOptions())
+		}
+		g.p("defer %v.End()", idSpan)
+		idRecovered := ia.allocateIdentifier("r")
+		g.p("defer func() {")
+		g.in()
+		g.p("if %v := recover(); %v != nil {", idRecovered, idRecovered)
+		g.in()
+		g.p(`%v.RecordError(%v("panic: %%v", %v), %v(true))`, idSpan, g.qualify("fmt", "Errorf"), idRecovered, g.qualify(otelTraceImportPath, "WithStackTrace"))
+		g.p(`%v.SetStatus(%v, %v("panic: ", %v))`, idSpan, g.qualify(otelCodesImportPath, "Error"), g.qualify("fmt", "Sprint"), idRecovered)
+		g.p("panic(%v)", idRecovered)
+		g.out()
+		g.p("}")
+		g.out()
+		g.p("}()")
+		g.generateSpanAttributes(idSpan, g.attributes.recordedArgs(intf.Name, m, argNames))
 	}
 
 	var callArgs string
@@ -157,29 +356,26 @@ func generateTracedMethod(g *generator, mockType string, m *model.Method, pkgOve
 	if len(m.Out) == 0 {
 		g.p(`%s.delegate.%s(%s)`, idRecv, m.Name, callArgs)
 	} else {
-		returnsError := false
-		errorIndex := -1
 		returns := make([]string, len(rets))
-		for i, r := range rets {
+		for i := range rets {
 			returns[i] = ia.allocateIdentifier("ret")
-			if r == "error" {
-				returnsError = true
-				errorIndex = i
-			}
-
 		}
 
 		returnArgsString := strings.Join(returns, ", ")
 		g.p(`%s := %s.delegate.%s(%s)`, returnArgsString, idRecv, m.Name, callArgs)
 
-		if returnsError && isContextMethod {
-			g.p(`if %v != nil {`, returns[errorIndex])
+		if er := errorResultOf(m); er.index >= 0 && isTraced {
+			cond, err := er.errorCheck(returns, ia)
+			g.p(`if %v && %v.isFailure(%v) {`, cond, idRecv, err)
 			g.in()
-			g.p("%v.RecordError(%v)", idSpan, returns[errorIndex])
-			g.p("%v.SetStatus(codes.Error, %v.Error())", idSpan, returns[errorIndex])
+			g.p("%v.RecordError(%v)", idSpan, err)
+			g.p("%v.SetStatus(%v, %v.Error())", idSpan, g.qualify(otelCodesImportPath, "Error"), err)
 			g.out()
 			g.p("}")
 		}
+		if isTraced {
+			g.generateSpanAttributes(idSpan, g.attributes.recordedResults(intf.Name, m, returns))
+		}
 		g.p(`return %s`, returnArgsString)
 
 	}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+var tracePackageMap = map[string]string{
+	"context":               "context",
+	"fmt":                   "fmt",
+	"example.com/users":     "users",
+	otelImportPath:          "otel",
+	otelAttributeImportPath: "attribute",
+	otelCodesImportPath:     "codes",
+	otelTraceImportPath:     "trace",
+}
+
+func TestGenerateTracedInterface_Attributes(t *testing.T) {
+	g := generator{
+		packageMap: tracePackageMap,
+		attributes: attributeOptions{
+			args:    parseSelectors("Store"),
+			results: parseSelectors("Store.Get"),
+			exclude: parseSelectors("token"),
+		},
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("string")},
+			{Name: "token", Type: model.PredeclaredType("string")},
+			{Name: "owner", Type: &model.PointerType{Type: &model.NamedType{Package: "example.com/users", Type: "User"}}},
+			{Name: "values", Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("byte")}},
+		},
+		Out: []*model.Parameter{
+			{Type: model.PredeclaredType("int")},
+			{Type: model.PredeclaredType("error")},
+		},
+	})
+
+	if err := generateTracedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		`span.SetAttributes(attribute.String("key", key))`,
+		`SpanAttributes(string) []attribute.KeyValue`,
+		`ok && owner != nil {`,
+		`span.SetAttributes(a.SpanAttributes("owner")...)`,
+		`span.SetAttributes(attribute.Int("result.0", ret))`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{`"token"`, `"values"`, `"result.1"`} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("generated code records %s:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestGenerateTracedInterface_ContextFree(t *testing.T) {
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "key", Type: model.PredeclaredType("string")},
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+		},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Put",
+		In: []*model.Parameter{
+			{Name: "req", Type: &model.PointerType{Type: &model.NamedType{Package: "example.com/users", Type: "Request"}}},
+		},
+	})
+	intf.AddMethod(&model.Method{
+		Name: "Len",
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}},
+	})
+
+	for _, tc := range []struct {
+		contextFree string
+		want        []string
+		unwanted    []string
+	}{
+		{
+			contextFree: contextFreeNone,
+			want:        []string{`ctx, span := t.tracer.Start(ctx, "Store.Get")`},
+			unwanted:    []string{`"Store.Put"`, `"Store.Len"`},
+		},
+		{
+			contextFree: contextFreeRoot,
+			want: []string{
+				`ctx, span := t.tracer.Start(ctx, "Store.Get")`,
+				`_, span := t.tracer.Start(context.Background(), "Store.Put")`,
+			},
+			unwanted: []string{`WithLinks`},
+		},
+		{
+			contextFree: contextFreeLink,
+			want: []string{
+				`ctx, span := t.tracer.Start(ctx, "Store.Get")`,
+				`if c, ok := any(req).(interface{ Context() context.Context }); ok && req != nil {`,
+				`links = append(links, trace.Link{SpanContext: sc})`,
+				`_, span := t.tracer.Start(context.Background(), "Store.Put", trace.WithLinks(links...))`,
+				// Methods without arguments carrying spans have no links.
+				"func (t *TracedStoreImpl) Len() int {\n\t_, span := t.tracer.Start(context.Background(), \"Store.Len\")",
+			},
+		},
+	} {
+		t.Run(tc.contextFree, func(t *testing.T) {
+			g := generator{packageMap: tracePackageMap, trace: traceOptions{contextFree: tc.contextFree}}
+			if err := generateTracedInterface(&g, intf, "somepackage"); err != nil {
+				t.Fatal(err)
+			}
+
+			out := g.buf.String()
+			for _, want := range tc.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("generated code does not contain %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("generated code contains %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTracedInterface_Naming(t *testing.T) {
+	spanName := template.Must(template.New("span_name").Parse("{{.Scope}}/{{.Method}}"))
+	for _, tc := range []struct {
+		name  string
+		trace traceOptions
+		want  []string
+	}{
+		{
+			name: "default",
+			want: []string{
+				`o.tracerProvider.Tracer("example.com/store")`,
+				`ctx, span := t.tracer.Start(ctx, "Store.Get")`,
+			},
+		},
+		{
+			name:  "options",
+			trace: traceOptions{scope: "shop", spanName: spanName, spanKind: "client"},
+			want: []string{
+				`o.tracerProvider.Tracer("shop")`,
+				`ctx, span := t.tracer.Start(ctx, "shop/Get", trace.WithSpanKind(trace.SpanKindClient))`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := generator{packageMap: tracePackageMap, pkgPath: "example.com/store", trace: tc.trace}
+			intf := &model.Interface{Name: "Store"}
+			intf.AddMethod(&model.Method{
+				Name: "Get",
+				In:   []*model.Parameter{{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}},
+			})
+			if err := generateTracedInterface(&g, intf, "somepackage"); err != nil {
+				t.Fatal(err)
+			}
+
+			out := g.buf.String()
+			for _, want := range append(tc.want,
+				`func WithTracedStoreImplTracerProvider(tp trace.TracerProvider) TracedStoreImplOption {`,
+				`o := tracedStoreImplOptions{tracerProvider: otel.GetTracerProvider(), isFailure: func(error) bool { return true }}`,
+			) {
+				if !strings.Contains(out, want) {
+					t.Errorf("generated code does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTracedInterface_WrapsSource(t *testing.T) {
+	packageMap := map[string]string{"example.com/store": "store"}
+	for k, v := range tracePackageMap {
+		packageMap[k] = v
+	}
+	g := generator{packageMap: packageMap, pkgPath: "example.com/store"}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateTracedInterface(&g, intf, "example.com/store/trace"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"delegate  store.Store",
+		"var _ store.Store = (*TracedStoreImpl)(nil)",
+		"func NewTracedStoreImpl(ctrl store.Store, opts ...TracedStoreImplOption) *TracedStoreImpl {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "interface {") {
+		t.Errorf("generated code redeclares the interface:\n%s", out)
+	}
+}
+
+func TestGenerateTracedInterface_Generic(t *testing.T) {
+	packageMap := map[string]string{"example.com/store": "store", "golang.org/x/exp/constraints": "constraints"}
+	for k, v := range tracePackageMap {
+		packageMap[k] = v
+	}
+	g := generator{packageMap: packageMap, pkgPath: "example.com/store"}
+	intf := &model.Interface{
+		Name: "Repository",
+		TypeParams: []*model.Parameter{
+			{Name: "K", Type: &model.NamedType{Package: "golang.org/x/exp/constraints", Type: "Ordered"}},
+			{Name: "V", Type: model.PredeclaredType("any")},
+		},
+	}
+	intf.AddMethod(&model.Method{
+		Name: "Get",
+		In: []*model.Parameter{
+			{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}},
+			{Name: "key", Type: model.PredeclaredType("K")},
+		},
+		Out: []*model.Parameter{
+			{Type: model.PredeclaredType("V")},
+			{Type: model.PredeclaredType("error")},
+		},
+	})
+
+	if err := generateTracedInterface(&g, intf, "example.com/store/trace"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"type TracedRepositoryImpl[K constraints.Ordered, V any] struct {",
+		"delegate  store.Repository[K, V]",
+		"func NewTracedRepositoryImpl[K constraints.Ordered, V any](ctrl store.Repository[K, V], opts ...TracedRepositoryImplOption) *TracedRepositoryImpl[K, V] {",
+		"func (t *TracedRepositoryImpl[K, V]) Get(ctx context.Context, key K) (V, error) {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "var _ ") {
+		t.Errorf("generated code asserts a generic interface without type arguments:\n%s", out)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+// This file contains the resolution of the types of the model, whose named
+// types it only holds the names of, with the type checker.
+
+import (
+	"go/importer"
+	"go/token"
+	"go/types"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+// typeResolver resolves the named types of the model, type-checking the
+// sources of their packages on first use.
+type typeResolver struct {
+	importer types.Importer
+	packages map[string]*types.Package // by import path; nil if they failed to load
+}
+
+// lookup returns the type named name in the package of the import path, or
+// nil if it cannot be resolved.
+func (r *typeResolver) lookup(path, name string) types.Type {
+	if r.packages == nil {
+		r.importer = importer.ForCompiler(token.NewFileSet(), "source", nil)
+		r.packages = make(map[string]*types.Package)
+	}
+	pkg, ok := r.packages[path]
+	if !ok {
+		if p, err := r.importer.Import(path); err == nil {
+			pkg = p
+		}
+		r.packages[path] = pkg
+	}
+	if pkg == nil {
+		return nil
+	}
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return obj.Type()
+}
+
+// resolve returns the type checker's type of the predeclared or named type t,
+// or nil if it cannot be resolved. The types of the source mode that are not
+// declared in the universe, as its unexported types, are looked up in the
+// package of the interfaces.
+func (g *generator) resolve(t model.Type) types.Type {
+	switch t := t.(type) {
+	case model.PredeclaredType:
+		if obj, ok := types.Universe.Lookup(string(t)).(*types.TypeName); ok {
+			return obj.Type()
+		}
+		if g.pkgPath != "" {
+			return g.resolver.lookup(g.pkgPath, string(t))
+		}
+	case *model.NamedType:
+		path := t.Package
+		if path == "" {
+			path = g.pkgPath
+		}
+		if path != "" {
+			return g.resolver.lookup(path, t.Type)
+		}
+	}
+	return nil
+}
+
+// isInterface reports whether t is an interface type, such as any or error.
+func (g *generator) isInterface(t model.Type) bool {
+	rt := g.resolve(t)
+	return rt != nil && types.IsInterface(rt)
+}
+
+// isComparable reports whether the values of t are comparable without
+// panicking, as map keys. The values of the interface types are not, as their
+// dynamic values may not be comparable. The types that cannot be resolved and
+// the type parameters are assumed to be.
+func (g *generator) isComparable(t model.Type) bool {
+	switch t := t.(type) {
+	case *model.ArrayType:
+		return t.Len >= 0 && g.isComparable(t.Type)
+	case *model.MapType, *model.FuncType:
+		return false
+	case *model.NamedType:
+		if t.TypeParams != nil {
+			for _, tp := range t.TypeParams.TypeParameters {
+				if !g.isComparable(tp) {
+					return false
+				}
+			}
+		}
+	}
+	if rt := g.resolve(t); rt != nil {
+		return isStrictlyComparable(rt)
+	}
+	return true
+}
+
+// isStrictlyComparable reports whether the values of t are comparable and
+// hold no interface value. The type parameters of the generic types are
+// assumed to be, their type arguments being checked in the model.
+func isStrictlyComparable(t types.Type) bool {
+	if _, ok := t.(*types.TypeParam); ok {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic, *types.Pointer, *types.Chan:
+		return true
+	case *types.Array:
+		return isStrictlyComparable(u.Elem())
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if !isStrictlyComparable(u.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package main
+
+// This file contains the unimplemented implementation, whose methods fail
+// with an unimplemented.ErrNotImplemented, to be embedded by the
+// implementations of evolving interfaces.
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+const unimplementedImportPath = "github.com/pableeee/implgen/unimplemented"
+
+// unimplementedImports are the packages referenced by the unimplemented
+// implementation.
+var unimplementedImports = map[string]string{
+	unimplementedImportPath: "",
+}
+
+// unimplementedOptions configures the unimplemented implementation.
+type unimplementedOptions struct {
+	guard bool // whether the embedding is enforced by a mustEmbed method
+}
+
+// The name of the mock type to use for the given interface identifier.
+func (g *generator) unimplementedName(typeName string) string {
+	if mockName, ok := g.mockNames[typeName]; ok {
+		return mockName
+	}
+
+	return "Unimplemented" + typeName
+}
+
+func generateUnimplementedInterface(g *generator, intf *model.Interface, outputPackagePath string) error {
+	mockType := g.unimplementedName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	intfType := g.sourceInterface(intf, outputPackagePath, shortTp)
+
+	g.p("")
+	g.p("// %v is an implementation of %v interface whose methods are not", mockType, intf.Name)
+	g.p("// implemented. It is embedded by the implementations that do not implement")
+	g.p("// every method, such as the methods added to the interface later.")
+	g.p("type %v%v struct{}", mockType, longTp)
+	g.p("")
+	g.generateInterfaceAssertion(intf, intfType, mockType)
+
+	if g.unimplemented.guard {
+		guard := "mustEmbed" + mockType
+		g.p("// MustEmbed%v is implemented by the implementations of %v", mockType, intf.Name)
+		g.p("// embedding %v, which APIs can require for the", mockType)
+		g.p("// implementations to stay compilable when methods are added to it.")
+		g.p("type MustEmbed%v%v interface {", mockType, longTp)
+		g.in()
+		g.p("%v", intfType)
+		g.p("%v()", guard)
+		g.out()
+		g.p("}")
+		g.p("")
+		g.p("func (%v%v) %v() {}", mockType, shortTp, guard)
+	}
+
+	sort.Sort(byMethodName(intf.Methods))
+	for _, m := range intf.Methods {
+		g.p("")
+		generateUnimplementedMethod(g, mockType, intf, m, outputPackagePath, shortTp)
+	}
+	return nil
+}
+
+func generateUnimplementedMethod(g *generator, mockType string, intf *model.Interface, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true)
+	argTypes := g.getArgTypes(m, pkgOverride, true)
+	argString := makeArgString(argNames, argTypes)
+	rets, retString := g.makeRetString(m, pkgOverride)
+
+	ia := newIdentifierAllocator(argNames)
+	err := fmt.Sprintf("&%v{Interface: %q, Method: %q}", g.qualify(unimplementedImportPath, "ErrNotImplemented"), intf.Name, m.Name)
+
+	g.p("// %v unimplemented base method.", m.Name)
+	g.p("func (%v%v) %v(%v)%v {", mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	if returnsError(m) {
+		returns := make([]string, len(rets))
+		for i := range rets {
+			returns[i] = ia.allocateIdentifier("ret")
+		}
+		g.generateErrorReturn(m, rets, returns, err)
+	} else {
+		// Without a result of type error, the methods can only panic.
+		g.p("panic(%v)", err)
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pableeee/implgen/mockgen/model"
+)
+
+func TestGenerateUnimplementedInterface(t *testing.T) {
+	g := generator{
+		packageMap:    map[string]string{"context": "context", unimplementedImportPath: "unimplemented"},
+		unimplemented: unimplementedOptions{guard: true},
+	}
+	intf := &model.Interface{Name: "Plugin"}
+	intf.AddMethod(&model.Method{
+		Name: "Load",
+		In:   []*model.Parameter{{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}},
+		Out:  []*model.Parameter{{Type: model.PredeclaredType("string")}, {Type: model.PredeclaredType("error")}},
+	})
+	intf.AddMethod(&model.Method{Name: "Close"})
+
+	if err := generateUnimplementedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := g.buf.String()
+	for _, want := range []string{
+		"type UnimplementedPlugin struct{}",
+		"var _ Plugin = (*UnimplementedPlugin)(nil)",
+		"type MustEmbedUnimplementedPlugin interface {\n\tPlugin\n\tmustEmbedUnimplementedPlugin()\n}",
+		"func (UnimplementedPlugin) mustEmbedUnimplementedPlugin() {}",
+		"func (UnimplementedPlugin) Load(ctx context.Context) (string, error) {\n" +
+			"\tvar ret string\n" +
+			"\treturn ret, &unimplemented.ErrNotImplemented{Interface: \"Plugin\", Method: \"Load\"}\n}",
+		"func (UnimplementedPlugin) Close() {\n" +
+			"\tpanic(&unimplemented.ErrNotImplemented{Interface: \"Plugin\", Method: \"Close\"})\n}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+
+	g = generator{packageMap: g.packageMap}
+	if err := generateUnimplementedInterface(&g, intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+	if out := g.buf.String(); strings.Contains(out, "mustEmbed") {
+		t.Errorf("generated code has a guard without -unimplemented_guard:\n%s", out)
+	}
+}
@@ -0,0 +1,69 @@
+// Package multi implements the dispatch of the calls of the multiplexers
+// generated by mockgen with -implementation_type=multi.
+package multi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Strategy selects the results returned by the methods of a multiplexer,
+// among the results of its implementations, when no merge function is set.
+// Whatever the strategy, every implementation is called.
+type Strategy int
+
+const (
+	// FirstSuccess returns the results of the first implementation, in
+	// order, that succeeded, or the errors of every implementation joined
+	// with errors.Join when none did.
+	FirstSuccess Strategy = iota
+	// FirstResult returns the results of the first implementation, whether
+	// it succeeded or not. The other implementations are still called.
+	FirstResult
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case FirstSuccess:
+		return "first-success"
+	case FirstResult:
+		return "first-result"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// Dispatch calls call with the indexes of n implementations, in order or in
+// parallel, and returns once every call returned. When parallel calls
+// panic, Dispatch panics with the value of the first one once every call
+// returned.
+func Dispatch(n int, parallel bool, call func(i int)) {
+	if !parallel || n < 2 {
+		for i := 0; i < n; i++ {
+			call(i)
+		}
+		return
+	}
+
+	var (
+		wg        sync.WaitGroup
+		once      sync.Once
+		panicked  bool
+		recovered any
+	)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { panicked, recovered = true, r })
+				}
+			}()
+			call(i)
+		}(i)
+	}
+	wg.Wait()
+	if panicked {
+		panic(recovered)
+	}
+}
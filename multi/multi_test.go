@@ -0,0 +1,61 @@
+package multi
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDispatch(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		var (
+			mu    sync.Mutex
+			calls []int
+		)
+		Dispatch(3, parallel, func(i int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, i)
+		})
+		if len(calls) != 3 {
+			t.Errorf("Dispatch(parallel=%v) made the calls %v, want 3", parallel, calls)
+		}
+		if !parallel && (calls[0] != 0 || calls[1] != 1 || calls[2] != 2) {
+			t.Errorf("Dispatch() made the calls %v, want them in order", calls)
+		}
+	}
+}
+
+func TestDispatch_Panic(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Dispatch() panicked with %v, want boom", r)
+		}
+		if calls != 3 {
+			t.Errorf("Dispatch() made %d calls before panicking, want 3", calls)
+		}
+	}()
+	Dispatch(3, true, func(i int) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		if i == 1 {
+			panic("boom")
+		}
+	})
+}
+
+func TestStrategy_String(t *testing.T) {
+	for s, want := range map[Strategy]string{
+		FirstSuccess: "first-success",
+		FirstResult:  "first-result",
+		Strategy(7):  "Strategy(7)",
+	} {
+		if got := s.String(); got != want {
+			t.Errorf("Strategy(%d).String() = %q, want %q", int(s), got, want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package ratelimit implements the limiters of the decorators generated by
+// mockgen with -implementation_type=ratelimit.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrLimited is matched by the errors of the calls rejected by a limiter, as
+// in errors.Is(err, ErrLimited).
+var ErrLimited = errors.New("call limit exceeded")
+
+// Reasons of the rejections of a limiter.
+const (
+	ReasonRate        = "rate"        // no token was left in the bucket
+	ReasonConcurrency = "concurrency" // every in-flight call slot was taken
+)
+
+// LimitError is the error of a call rejected by a limiter.
+type LimitError struct {
+	Name   string // name of the limiter
+	Reason string // ReasonRate or ReasonConcurrency
+
+	// Err is the error of the context of the call if it was done before the
+	// call could proceed, nil if the call did not wait.
+	Err error
+}
+
+func (e *LimitError) Error() string {
+	msg := fmt.Sprintf("%v: %v limit exceeded", e.Name, e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is ErrLimited.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimited
+}
+
+// Unwrap returns the error of the context of the call, if any.
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// Clock tells the time to the limiters, and times their waits for tokens.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel receiving the time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Limits configures a limiter. The zero value does not limit anything.
+type Limits struct {
+	// Rate is the number of calls per second that the token bucket lets
+	// through, if positive.
+	Rate float64
+	// Burst is the size of the token bucket, which starts full. Defaults to
+	// Rate, rounded up.
+	Burst int
+	// MaxConcurrent is the maximum number of calls in flight, if positive.
+	MaxConcurrent int
+	// Clock defaults to the system clock.
+	Clock Clock
+}
+
+// Limiter limits the rate and the concurrency of calls. It is safe for
+// concurrent use.
+type Limiter struct {
+	name   string
+	limits Limits
+	slots  chan struct{} // nil if the concurrency is not limited
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time // when tokens was last refilled
+}
+
+// New returns a limiter named name.
+func New(name string, l Limits) *Limiter {
+	if l.Rate > 0 && l.Burst <= 0 {
+		l.Burst = int(math.Ceil(l.Rate))
+	}
+	if l.Clock == nil {
+		l.Clock = systemClock{}
+	}
+	lim := &Limiter{
+		name:   name,
+		limits: l,
+		tokens: float64(l.Burst),
+		last:   l.Clock.Now(),
+	}
+	if l.MaxConcurrent > 0 {
+		lim.slots = make(chan struct{}, l.MaxConcurrent)
+	}
+	return lim
+}
+
+// Name returns the name of the limiter.
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// reserve takes a token from the bucket and returns how long to wait before
+// it is available. If the wait would exceed maxWait, it takes nothing and
+// returns false.
+func (l *Limiter) reserve(maxWait time.Duration) (time.Duration, bool) {
+	if l.limits.Rate <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.limits.Clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.limits.Rate
+		if l.tokens > float64(l.limits.Burst) {
+			l.tokens = float64(l.limits.Burst)
+		}
+	}
+	l.last = now
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) / l.limits.Rate * float64(time.Second))
+	}
+	if wait > maxWait {
+		return 0, false
+	}
+	l.tokens--
+	return wait, true
+}
+
+// unreserve gives back a token taken by reserve.
+func (l *Limiter) unreserve() {
+	if l.limits.Rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+func (l *Limiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// TryAcquire lets a call proceed if it is within the limits, without
+// waiting, and otherwise returns a *LimitError. The returned function has to
+// be called once the call is over. The calls rejected for their concurrency
+// do not take a token.
+func (l *Limiter) TryAcquire() (release func(), err error) {
+	if _, ok := l.reserve(0); !ok {
+		return nil, &LimitError{Name: l.name, Reason: ReasonRate}
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			l.unreserve()
+			return nil, &LimitError{Name: l.name, Reason: ReasonConcurrency}
+		}
+	}
+	return l.release, nil
+}
+
+// Acquire waits until a call is within the limits and returns the function
+// that has to be called once the call is over. It returns a *LimitError
+// wrapping the error of ctx if ctx is done first, or right away if the
+// deadline of ctx would expire first. The calls rejected for their
+// concurrency do not take a token.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(l.limits.Clock.Now())
+	}
+	wait, ok := l.reserve(maxWait)
+	if !ok {
+		return nil, &LimitError{Name: l.name, Reason: ReasonRate, Err: context.DeadlineExceeded}
+	}
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			l.unreserve()
+			return nil, &LimitError{Name: l.name, Reason: ReasonRate, Err: ctx.Err()}
+		case <-l.limits.Clock.After(wait):
+		}
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			l.unreserve()
+			return nil, &LimitError{Name: l.name, Reason: ReasonConcurrency, Err: ctx.Err()}
+		}
+	}
+	return l.release, nil
+}
+
+// NewMethods returns the limiters of the given methods of the interface intf,
+// by method name, named as Interface.Method. They are created with the limits
+// of the method in perMethod, if any, and otherwise with def. It panics if
+// perMethod holds the limits of another method.
+func NewMethods(def Limits, perMethod map[string]Limits, intf string, methods ...string) map[string]*Limiter {
+	limiters := make(map[string]*Limiter, len(methods))
+	for _, m := range methods {
+		l, ok := perMethod[m]
+		if !ok {
+			l = def
+		}
+		limiters[m] = New(intf+"."+m, l)
+	}
+	for m := range perMethod {
+		if _, ok := limiters[m]; !ok {
+			panic(fmt.Sprintf("ratelimit: %v.%v is not a limited method", intf, m))
+		}
+	}
+	return limiters
+}
@@ -0,0 +1,190 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when told to.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	return t.c
+}
+
+// Advance moves the time by d, firing the timers due by then.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	var pending []fakeTimer
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// waitTimers waits until n timers are pending.
+func (c *fakeClock) waitTimers(n int) {
+	for {
+		c.mu.Lock()
+		pending := len(c.timers)
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := New("Store.Get", Limits{Rate: 2, Clock: clock})
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.TryAcquire(); err != nil {
+			t.Fatalf("TryAcquire() #%d = %v, want nil", i, err)
+		}
+	}
+	_, err := l.TryAcquire()
+	var limitErr *LimitError
+	if !errors.Is(err, ErrLimited) || !errors.As(err, &limitErr) || limitErr.Reason != ReasonRate {
+		t.Fatalf("TryAcquire() = %v, want a rate *LimitError", err)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if _, err := l.TryAcquire(); err != nil {
+		t.Errorf("TryAcquire() = %v after a refill, want nil", err)
+	}
+}
+
+func TestLimiter_Concurrency(t *testing.T) {
+	l := New("Store.Get", Limits{MaxConcurrent: 1})
+
+	release, err := l.TryAcquire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = l.TryAcquire()
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Reason != ReasonConcurrency {
+		t.Fatalf("TryAcquire() = %v, want a concurrency *LimitError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, ErrLimited) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() = %v, want a *LimitError wrapping %v", err, context.Canceled)
+	}
+
+	release()
+	if _, err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("Acquire() = %v after a release, want nil", err)
+	}
+}
+
+func TestLimiter_ConcurrencyKeepsTokens(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := New("Store.Get", Limits{Rate: 1, Burst: 3, MaxConcurrent: 1, Clock: clock})
+
+	if _, err := l.TryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.TryAcquire(); !errors.Is(err, ErrLimited) {
+		t.Fatalf("TryAcquire() = %v, want a concurrency *LimitError", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, ErrLimited) {
+		t.Fatalf("Acquire() = %v, want a concurrency *LimitError", err)
+	}
+	// Only the call that proceeded took a token.
+	if l.tokens != 2 {
+		t.Errorf("tokens = %v after the concurrency rejections, want 2", l.tokens)
+	}
+}
+
+func TestLimiter_AcquireWaits(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := New("Store.Get", Limits{Rate: 1, Burst: 1, Clock: clock})
+	if _, err := l.TryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan error)
+	go func() {
+		_, err := l.Acquire(context.Background())
+		acquired <- err
+	}()
+	clock.waitTimers(1)
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case err := <-acquired:
+		t.Fatalf("Acquire() = %v before the token was available", err)
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	if err := <-acquired; err != nil {
+		t.Errorf("Acquire() = %v, want nil", err)
+	}
+}
+
+func TestLimiter_AcquireDeadline(t *testing.T) {
+	l := New("Store.Get", Limits{Rate: 1, Burst: 1})
+	if _, err := l.TryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	if _, err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() = %v, want a *LimitError wrapping %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(begin); elapsed > 5*time.Millisecond {
+		t.Errorf("Acquire() waited %v for a token past its deadline", elapsed)
+	}
+}
+
+func TestNewMethods(t *testing.T) {
+	limiters := NewMethods(Limits{}, map[string]Limits{"Put": {MaxConcurrent: 1}}, "Store", "Get", "Put")
+	if limiters["Put"].limits.MaxConcurrent != 1 || limiters["Get"].limits.MaxConcurrent != 0 {
+		t.Errorf("NewMethods() did not apply the limits by method")
+	}
+	if got := limiters["Get"].Name(); got != "Store.Get" {
+		t.Errorf("Name() = %q, want Store.Get", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewMethods() did not panic on the limits of an unknown method")
+		}
+	}()
+	NewMethods(Limits{}, map[string]Limits{"Delete": {}}, "Store", "Get")
+}
@@ -0,0 +1,215 @@
+// Package record implements the recording and the replay of the calls of the
+// decorators generated by mockgen with -implementation_type=record.
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Call is a recorded call, a line of the JSON-lines recordings.
+type Call struct {
+	Method  string            `json:"method"`          // Interface.Method name
+	Args    []json.RawMessage `json:"args"`            // arguments, but their context
+	Results []json.RawMessage `json:"results"`         // results, but their error
+	Error   *string           `json:"error,omitempty"` // message of the error, if the call failed
+}
+
+// Codec encodes the arguments and the results of the calls. Codecs wrapping
+// JSON can handle the values that encoding/json does not.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// JSON is the Codec encoding the values with encoding/json.
+var JSON Codec = jsonCodec{}
+
+// Recorder writes the calls to a JSON-lines recording.
+type Recorder struct {
+	codec Codec
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder returns a Recorder writing to w, encoding the values with
+// codec, or JSON if nil.
+func NewRecorder(w io.Writer, codec Codec) *Recorder {
+	if codec == nil {
+		codec = JSON
+	}
+	return &Recorder{codec: codec, enc: json.NewEncoder(w)}
+}
+
+// Record writes a call of method. The calls that cannot be encoded or
+// written are not recorded, and their first error is returned by Err.
+func (r *Recorder) Record(method string, args, results []any, err error) {
+	c := Call{Method: method}
+	var encErr error
+	if c.Args, encErr = r.encode(args); encErr != nil {
+		r.fail(fmt.Errorf("record: %v: %w", method, encErr))
+		return
+	}
+	if c.Results, encErr = r.encode(results); encErr != nil {
+		r.fail(fmt.Errorf("record: %v: %w", method, encErr))
+		return
+	}
+	if err != nil {
+		msg := err.Error()
+		c.Error = &msg
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if encErr := r.enc.Encode(c); encErr != nil && r.err == nil {
+		r.err = fmt.Errorf("record: %v: %w", method, encErr)
+	}
+}
+
+func (r *Recorder) encode(values []any) ([]json.RawMessage, error) {
+	raw := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		data, err := r.codec.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw[i] = data
+	}
+	return raw, nil
+}
+
+func (r *Recorder) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the first error of the calls that were not recorded.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReplayError is the error of a call that cannot be replayed.
+type ReplayError struct {
+	Method string // Interface.Method name
+	Call   int    // index of the call among the calls of Method
+	Reason string
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("record: call %d of %v: %v", e.Call, e.Method, e.Reason)
+}
+
+// Replayer serves the results of the calls of a recording. The calls of
+// each method are replayed in the order they were recorded in.
+type Replayer struct {
+	codec Codec
+
+	mu       sync.Mutex
+	calls    map[string][]Call // calls left, by method
+	replayed map[string]int    // calls replayed, by method
+}
+
+// NewReplayer returns a Replayer of the recording read from r, decoding the
+// values with codec, or JSON if nil.
+func NewReplayer(r io.Reader, codec Codec) (*Replayer, error) {
+	if codec == nil {
+		codec = JSON
+	}
+	calls := make(map[string][]Call)
+	dec := json.NewDecoder(r)
+	for {
+		var c Call
+		if err := dec.Decode(&c); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("record: %w", err)
+		}
+		calls[c.Method] = append(calls[c.Method], c)
+	}
+	return &Replayer{codec: codec, calls: calls, replayed: make(map[string]int)}, nil
+}
+
+// Replay replays the next call of method, verifying that its arguments are
+// the recorded ones and decoding its results into the pointers results. It
+// returns the recorded error, or a *ReplayError if the call cannot be
+// replayed.
+func (r *Replayer) Replay(method string, args []any, results ...any) error {
+	r.mu.Lock()
+	calls := r.calls[method]
+	n := r.replayed[method]
+	if len(calls) > 0 {
+		r.calls[method] = calls[1:]
+		r.replayed[method]++
+	}
+	r.mu.Unlock()
+
+	fail := func(format string, a ...any) error {
+		return &ReplayError{Method: method, Call: n, Reason: fmt.Sprintf(format, a...)}
+	}
+	if len(calls) == 0 {
+		return fail("not recorded")
+	}
+	c := calls[0]
+	if len(args) != len(c.Args) {
+		return fail("got %d arguments, recorded %d", len(args), len(c.Args))
+	}
+	for i, arg := range args {
+		data, err := r.codec.Marshal(arg)
+		if err != nil {
+			return fail("argument %d: %v", i, err)
+		}
+		if !equalJSON(data, c.Args[i]) {
+			return fail("argument %d is %s, recorded %s", i, data, c.Args[i])
+		}
+	}
+	if len(results) != len(c.Results) {
+		return fail("got %d results, recorded %d", len(results), len(c.Results))
+	}
+	for i, res := range results {
+		if err := r.codec.Unmarshal(c.Results[i], res); err != nil {
+			return fail("result %d: %v", i, err)
+		}
+	}
+	if c.Error != nil {
+		return errors.New(*c.Error)
+	}
+	return nil
+}
+
+// equalJSON reports whether a and b are the same JSON, but for their
+// insignificant spaces.
+func equalJSON(a, b []byte) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// Remaining returns the number of recorded calls that were not replayed.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, calls := range r.calls {
+		n += len(calls)
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf, nil)
+	rec.Record("Store.Get", []any{"a"}, []any{[]byte("1")}, nil)
+	rec.Record("Store.Get", []any{"b"}, []any{[]byte(nil)}, errors.New("not found"))
+	rec.Record("Store.Keys", []any{"", 10}, []any{[]string{"a"}}, nil)
+	if err := rec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Fatalf("recording has %d lines, want 3:\n%s", n, buf.String())
+	}
+
+	rep, err := NewReplayer(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The calls of each method are replayed in order, whatever the order of
+	// the methods.
+	var keys []string
+	if err := rep.Replay("Store.Keys", []any{"", 10}, &keys); err != nil || len(keys) != 1 || keys[0] != "a" {
+		t.Errorf("Replay(Keys) = %q, %v, want [a], nil", keys, err)
+	}
+	var v []byte
+	if err := rep.Replay("Store.Get", []any{"a"}, &v); err != nil || string(v) != "1" {
+		t.Errorf("Replay(Get a) = %q, %v, want 1, nil", v, err)
+	}
+	if err := rep.Replay("Store.Get", []any{"b"}, &v); err == nil || err.Error() != "not found" {
+		t.Errorf("Replay(Get b) = %v, want the recorded error", err)
+	}
+	if n := rep.Remaining(); n != 0 {
+		t.Errorf("Remaining() = %d, want 0", n)
+	}
+
+	var replayErr *ReplayError
+	if err := rep.Replay("Store.Get", []any{"c"}, &v); !errors.As(err, &replayErr) || replayErr.Call != 2 {
+		t.Errorf("Replay(Get c) = %v, want a *ReplayError of call 2", err)
+	}
+}
+
+func TestReplay_Mismatch(t *testing.T) {
+	rep, err := NewReplayer(strings.NewReader(`{"method":"Store.Get","args":["a"],"results":[null]}`+"\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v []byte
+	err = rep.Replay("Store.Get", []any{"b"}, &v)
+	want := `record: call 0 of Store.Get: argument 0 is "b", recorded "a"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Replay() = %v, want %v", err, want)
+	}
+}
+
+// funcCodec is a Codec encoding the functions as their presence.
+type funcCodec struct {
+	Codec
+}
+
+func (c funcCodec) Marshal(v any) ([]byte, error) {
+	if f, ok := v.(func()); ok {
+		return json.Marshal(f != nil)
+	}
+	return c.Codec.Marshal(v)
+}
+
+func TestRecord_Codec(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf, nil)
+	rec.Record("Store.Watch", []any{func() {}}, nil, nil)
+	if rec.Err() == nil || buf.Len() != 0 {
+		t.Errorf("Record() recorded a function with the JSON codec: %v, %q", rec.Err(), buf.String())
+	}
+
+	rec = NewRecorder(&buf, funcCodec{JSON})
+	rec.Record("Store.Watch", []any{func() {}}, nil, nil)
+	if err := rec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	rep, err := NewReplayer(&buf, funcCodec{JSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rep.Replay("Store.Watch", []any{func() {}}); err != nil {
+		t.Errorf("Replay() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Package retry implements the retry policies of the decorators generated by
+// mockgen with -implementation_type=retry.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Policy decides whether and when the failed calls of a decorator are
+// retried.
+type Policy interface {
+	// Retry reports whether to retry a call that failed with err after the
+	// given number of attempts, and the delay before retrying it.
+	Retry(attempts int, err error) (time.Duration, bool)
+}
+
+// Defaults of the Backoff fields.
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 100 * time.Millisecond
+	DefaultMultiplier  = 2
+)
+
+// Backoff is a Policy retrying calls after exponentially increasing delays,
+// randomized by a jitter. The zero value is ready to use.
+type Backoff struct {
+	// MaxAttempts is the maximum number of attempts of a call, including
+	// the first. Defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Defaults to
+	// DefaultBaseDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the delays, if positive.
+	MaxDelay time.Duration
+	// Multiplier is the factor by which the delay grows after each retry.
+	// Defaults to DefaultMultiplier.
+	Multiplier float64
+	// Jitter is the fraction of each delay, between 0 and 1, that is
+	// randomized.
+	Jitter float64
+	// Retryable reports whether a call that failed with err may be retried.
+	// Every error is retryable if nil.
+	Retryable func(err error) bool
+}
+
+// Retry implements Policy.
+func (b Backoff) Retry(attempts int, err error) (time.Duration, bool) {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if attempts >= maxAttempts || (b.Retryable != nil && !b.Retryable(err)) {
+		return 0, false
+	}
+	return b.Delay(attempts), true
+}
+
+// Delay returns the delay before retrying a call after the given number of
+// attempts.
+func (b Backoff) Delay(attempts int) time.Duration {
+	delay := float64(b.BaseDelay)
+	if delay <= 0 {
+		delay = float64(DefaultBaseDelay)
+	}
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = DefaultMultiplier
+	}
+	for i := 1; i < attempts; i++ {
+		delay *= multiplier
+		if b.MaxDelay > 0 && delay >= float64(b.MaxDelay) {
+			break
+		}
+	}
+	if b.MaxDelay > 0 && delay > float64(b.MaxDelay) {
+		delay = float64(b.MaxDelay)
+	}
+	if b.Jitter > 0 {
+		delay -= delay * b.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// Do calls f until it returns nil, p gives up or ctx is done, and returns the
+// last error returned by f.
+func Do(ctx context.Context, p Policy, f func() error) error {
+	for attempts := 1; ; attempts++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		delay, ok := p.Retry(attempts, err)
+		if !ok || ctx.Err() != nil {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
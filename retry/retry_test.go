@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errFlaky = errors.New("flaky")
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for attempts, want := range map[int]time.Duration{
+		1: 10 * time.Millisecond,
+		2: 20 * time.Millisecond,
+		3: 40 * time.Millisecond,
+		4: 50 * time.Millisecond,
+		9: 50 * time.Millisecond,
+	} {
+		if got := b.Delay(attempts); got != want {
+			t.Errorf("Delay(%v) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := Backoff{BaseDelay: 100 * time.Millisecond, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if got := b.Delay(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("Delay(1) = %v, want between 50ms and 100ms", got)
+		}
+	}
+}
+
+func TestBackoffRetry(t *testing.T) {
+	b := Backoff{
+		MaxAttempts: 2,
+		Retryable:   func(err error) bool { return errors.Is(err, errFlaky) },
+	}
+	if _, ok := b.Retry(1, errFlaky); !ok {
+		t.Error("Retry(1, errFlaky) gave up, want a retry")
+	}
+	if _, ok := b.Retry(2, errFlaky); ok {
+		t.Error("Retry(2, errFlaky) retried past MaxAttempts")
+	}
+	if _, ok := b.Retry(1, errors.New("fatal")); ok {
+		t.Error("Retry(1, fatal) retried an error that is not retryable")
+	}
+}
+
+func TestDo(t *testing.T) {
+	p := Backoff{MaxAttempts: 5, BaseDelay: time.Microsecond}
+
+	calls := 0
+	err := Do(context.Background(), p, func() error {
+		calls++
+		if calls < 3 {
+			return errFlaky
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Do() = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	calls = 0
+	err = Do(context.Background(), p, func() error {
+		calls++
+		return errFlaky
+	})
+	if err != errFlaky || calls != 5 {
+		t.Errorf("Do() = %v after %d calls, want %v after 5", err, calls, errFlaky)
+	}
+}
+
+func TestDo_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := Backoff{MaxAttempts: 5, BaseDelay: time.Hour}
+
+	calls := 0
+	err := Do(ctx, p, func() error {
+		calls++
+		cancel()
+		return errFlaky
+	})
+	if err != errFlaky || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want %v after 1", err, calls, errFlaky)
+	}
+}
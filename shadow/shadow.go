@@ -0,0 +1,138 @@
+// Package shadow implements the shadow calls of the decorators generated by
+// mockgen with -implementation_type=shadow.
+package shadow
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pableeee/implgen/internal/detached"
+)
+
+// Defaults of the Settings fields.
+const (
+	DefaultMaxInFlight = 100
+	DefaultTimeout     = 10 * time.Second
+)
+
+// Settings configures a Runner. The zero value is ready to use, but reports
+// the mismatches to nobody.
+type Settings struct {
+	// MaxInFlight is the number of shadow calls running at once, beyond
+	// which the shadow calls are dropped. Defaults to DefaultMaxInFlight.
+	MaxInFlight int
+	// Timeout bounds the duration of the shadow calls, through their
+	// context. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Compare reports whether the results of the shadow call of a method
+	// match the ones of the primary call. Defaults to Equal.
+	Compare func(method string, primary, shadow []any) bool
+	// OnMismatch is called with the shadow calls whose results do not match
+	// the ones of the primary calls, or that panicked.
+	OnMismatch func(Mismatch)
+	// OnDropped is called with the Interface.Method name of the shadow calls
+	// dropped when MaxInFlight of them are running.
+	OnDropped func(method string)
+}
+
+// Mismatch is a shadow call whose results do not match the ones of the
+// primary call.
+type Mismatch struct {
+	Method  string // Interface.Method name
+	Args    []any  // arguments of the calls, but their context
+	Primary []any  // results of the primary call
+	Shadow  []any  // results of the shadow call; nil if it panicked
+	Panic   any    // value recovered from a panic of the shadow call, if any
+}
+
+// Runner runs the shadow calls in goroutines, bounding their number and
+// their duration.
+type Runner struct {
+	s   Settings
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// New returns a Runner configured with s.
+func New(s Settings) *Runner {
+	if s.MaxInFlight <= 0 {
+		s.MaxInFlight = DefaultMaxInFlight
+	}
+	if s.Timeout <= 0 {
+		s.Timeout = DefaultTimeout
+	}
+	if s.Compare == nil {
+		s.Compare = Equal
+	}
+	return &Runner{s: s, sem: make(chan struct{}, s.MaxInFlight)}
+}
+
+// Go runs call in a goroutine, unless MaxInFlight shadow calls are running,
+// and compares its results with the results of the primary call. It reports
+// whether call runs.
+//
+// call is passed a context carrying the values of ctx, the context of the
+// primary call, which is not canceled with it but once Timeout expires.
+func (r *Runner) Go(ctx context.Context, method string, args, primary []any, call func(ctx context.Context) []any) bool {
+	select {
+	case r.sem <- struct{}{}:
+	default:
+		if r.s.OnDropped != nil {
+			r.s.OnDropped(method)
+		}
+		return false
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.sem }()
+
+		ctx, cancel := context.WithTimeout(detached.WithoutCancel(ctx), r.s.Timeout)
+		defer cancel()
+		shadow, recovered := run(ctx, call)
+		if recovered == nil && r.s.Compare(method, primary, shadow) {
+			return
+		}
+		if r.s.OnMismatch != nil {
+			r.s.OnMismatch(Mismatch{Method: method, Args: args, Primary: primary, Shadow: shadow, Panic: recovered})
+		}
+	}()
+	return true
+}
+
+// run calls call, recovering from its panics.
+func run(ctx context.Context, call func(ctx context.Context) []any) (results []any, recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+	return call(ctx), nil
+}
+
+// Wait waits for the shadow calls that are running.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// Equal reports whether the results of the primary and the shadow calls are
+// deeply equal, as with reflect.DeepEqual, but for their errors, which are
+// equal when both are nil or have the same message.
+func Equal(_ string, primary, shadow []any) bool {
+	if len(primary) != len(shadow) {
+		return false
+	}
+	for i := range primary {
+		pe, pok := primary[i].(error)
+		se, sok := shadow[i].(error)
+		switch {
+		case pok && sok:
+			if pe.Error() != se.Error() {
+				return false
+			}
+		case !reflect.DeepEqual(primary[i], shadow[i]):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunner_Go(t *testing.T) {
+	var mismatches []Mismatch
+	r := New(Settings{OnMismatch: func(m Mismatch) { mismatches = append(mismatches, m) }})
+
+	call := func(results ...any) func(context.Context) []any {
+		return func(context.Context) []any { return results }
+	}
+	r.Go(context.Background(), "Store.Get", []any{"a"}, []any{"1", nil}, call("1", nil))
+	r.Wait()
+	r.Go(context.Background(), "Store.Get", []any{"b"}, []any{"1", nil}, call("2", nil))
+	r.Wait()
+	r.Go(context.Background(), "Store.Get", []any{"c"}, []any{"1", nil}, func(context.Context) []any { panic("boom") })
+	r.Wait()
+
+	if len(mismatches) != 2 {
+		t.Fatalf("mismatches = %+v, want 2", mismatches)
+	}
+	if m := mismatches[0]; m.Method != "Store.Get" || m.Args[0] != "b" || m.Shadow[0] != "2" || m.Panic != nil {
+		t.Errorf("mismatch = %+v, want the results of Get(b)", m)
+	}
+	if m := mismatches[1]; m.Args[0] != "c" || m.Shadow != nil || m.Panic != "boom" {
+		t.Errorf("mismatch = %+v, want the panic of Get(c)", m)
+	}
+}
+
+func TestRunner_GoDropped(t *testing.T) {
+	var dropped []string
+	r := New(Settings{MaxInFlight: 1, OnDropped: func(method string) { dropped = append(dropped, method) }})
+
+	release := make(chan struct{})
+	blocked := func(context.Context) []any {
+		<-release
+		return nil
+	}
+	if !r.Go(context.Background(), "Store.Get", nil, nil, blocked) {
+		t.Error("Go() dropped the first call")
+	}
+	if r.Go(context.Background(), "Store.Put", nil, nil, blocked) {
+		t.Error("Go() ran a call beyond MaxInFlight")
+	}
+	close(release)
+	r.Wait()
+	if len(dropped) != 1 || dropped[0] != "Store.Put" {
+		t.Errorf("dropped = %q, want [Store.Put]", dropped)
+	}
+}
+
+type valueKey struct{}
+
+func TestRunner_GoContext(t *testing.T) {
+	r := New(Settings{Timeout: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), valueKey{}, "v"))
+	cancel()
+	var (
+		value any
+		err   error
+	)
+	r.Go(ctx, "Store.Get", nil, nil, func(ctx context.Context) []any {
+		if ctx.Err() != nil {
+			t.Error("the shadow call is canceled with the primary call")
+		}
+		<-ctx.Done()
+		value, err = ctx.Value(valueKey{}), ctx.Err()
+		return nil
+	})
+	r.Wait()
+	if value != "v" || err != context.DeadlineExceeded {
+		t.Errorf("shadow context = %v, %v, want v, %v", value, err, context.DeadlineExceeded)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		primary, shadow []any
+		want            bool
+	}{
+		{"equal", []any{[]string{"a"}, nil}, []any{[]string{"a"}, nil}, true},
+		{"results", []any{[]string{"a"}, nil}, []any{[]string{"b"}, nil}, false},
+		{"same errors", []any{errors.New("not found")}, []any{errors.New("not found")}, true},
+		{"errors", []any{errors.New("not found")}, []any{errors.New("unavailable")}, false},
+		{"error and nil", []any{errors.New("not found")}, []any{nil}, false},
+		{"lengths", []any{1}, nil, false},
+	} {
+		if got := Equal("Store.Get", tc.primary, tc.shadow); got != tc.want {
+			t.Errorf("%s: Equal() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package singleflight implements the coalescing of the calls of the
+// decorators generated by mockgen with -implementation_type=singleflight.
+package singleflight
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pableeee/implgen/internal/detached"
+)
+
+// Group coalesces the concurrent calls with equal keys. The zero value is
+// ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[any]*call
+}
+
+// call is an in-flight call.
+type call struct {
+	done    chan struct{} // closed when the call returns
+	waiters int           // callers waiting for the results
+	cancel  context.CancelFunc
+
+	results   any
+	panicked  bool
+	recovered any // value recovered from a panic of the call
+}
+
+// Do calls fn and returns its results, unless a call with an equal key is in
+// flight, whose results it returns instead. Every caller waits until its ctx
+// is done, and then returns the error of ctx.
+//
+// fn is called in a goroutine, with a context carrying the values of the ctx
+// of the first caller, which is canceled once every caller stopped waiting.
+// If fn panics, Do panics in every caller with the same value.
+func (g *Group) Do(ctx context.Context, key any, fn func(ctx context.Context) any) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[any]*call)
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.panicked {
+			panic(c.recovered)
+		}
+		return c.results, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		if c.waiters--; c.waiters == 0 {
+			// Nobody waits for the results: the next calls start anew.
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (g *Group) run(ctx context.Context, key any, c *call, fn func(ctx context.Context) any) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.panicked, c.recovered = true, r
+		}
+		g.mu.Lock()
+		g.forget(key, c)
+		g.mu.Unlock()
+		c.cancel()
+		close(c.done)
+	}()
+	c.results = fn(ctx)
+}
+
+// forget removes c from the in-flight calls. g.mu must be held.
+func (g *Group) forget(key any, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
+
+// WithoutCancel returns a context carrying the values of parent, which is
+// never done.
+func WithoutCancel(parent context.Context) context.Context {
+	return detached.WithoutCancel(parent)
+}
@@ -0,0 +1,103 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroup_Do(t *testing.T) {
+	var g Group
+	release := make(chan struct{})
+	calls := 0
+	fn := func(context.Context) any {
+		calls++
+		<-release
+		return "v"
+	}
+
+	var wg sync.WaitGroup
+	results := make([]any, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := g.Do(context.Background(), "k", fn)
+			if err != nil {
+				t.Errorf("Do() = %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	// Wait for every caller to join the call.
+	for waiters := 0; waiters < 3; {
+		time.Sleep(time.Millisecond)
+		g.mu.Lock()
+		if c := g.calls["k"]; c != nil {
+			waiters = c.waiters
+		}
+		g.mu.Unlock()
+	}
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("fn was called %d times, want 1", calls)
+	}
+	for i, v := range results {
+		if v != "v" {
+			t.Errorf("caller %d got %v, want v", i, v)
+		}
+	}
+}
+
+func TestGroup_DoCanceled(t *testing.T) {
+	var g Group
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := g.Do(ctx, "k", func(ctx context.Context) any {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Do() = %v, want %v", err, context.Canceled)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("the call was not canceled once its only caller stopped waiting")
+	}
+}
+
+func TestGroup_DoPanic(t *testing.T) {
+	var g Group
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Do() panicked with %v, want boom", r)
+		}
+	}()
+	_, _ = g.Do(context.Background(), "k", func(context.Context) any { panic("boom") })
+}
+
+type valueKey struct{}
+
+func TestWithoutCancel(t *testing.T) {
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), valueKey{}, "v"))
+	cancel()
+	ctx := WithoutCancel(parent)
+	if ctx.Err() != nil || ctx.Done() != nil {
+		t.Error("WithoutCancel() is done with its parent")
+	}
+	if v := ctx.Value(valueKey{}); v != "v" {
+		t.Errorf("Value() = %v, want v", v)
+	}
+}
@@ -0,0 +1,112 @@
+// Package synchronized implements the context-aware locking of the decorators
+// generated by mockgen with -implementation_type=synchronized and
+// -synchronized_context.
+package synchronized
+
+import (
+	"context"
+	"sync"
+)
+
+// RWMutex is a reader/writer mutual exclusion lock, whose locks can be
+// acquired with a context. As with sync.RWMutex, a blocked Lock call excludes
+// new readers from acquiring the lock. The zero value is an unlocked mutex.
+type RWMutex struct {
+	mu      sync.Mutex
+	readers int           // readers holding the lock
+	writer  bool          // whether a writer holds the lock
+	waiting int           // writers waiting for the lock
+	changed chan struct{} // closed when the lock is released, or nil
+}
+
+// Lock locks m for writing.
+func (m *RWMutex) Lock() {
+	_ = m.LockContext(context.Background())
+}
+
+// LockContext locks m for writing, unless ctx is done first, in which case
+// it returns the error of ctx.
+func (m *RWMutex) LockContext(ctx context.Context) error {
+	m.mu.Lock()
+	m.waiting++
+	for m.writer || m.readers > 0 {
+		if err := m.wait(ctx); err != nil {
+			m.waiting--
+			m.broadcast()
+			m.mu.Unlock()
+			return err
+		}
+	}
+	m.waiting--
+	m.writer = true
+	m.mu.Unlock()
+	return nil
+}
+
+// Unlock unlocks m for writing.
+func (m *RWMutex) Unlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.writer {
+		panic("synchronized: Unlock of unlocked RWMutex")
+	}
+	m.writer = false
+	m.broadcast()
+}
+
+// RLock locks m for reading.
+func (m *RWMutex) RLock() {
+	_ = m.RLockContext(context.Background())
+}
+
+// RLockContext locks m for reading, unless ctx is done first, in which case
+// it returns the error of ctx.
+func (m *RWMutex) RLockContext(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.writer || m.waiting > 0 {
+		if err := m.wait(ctx); err != nil {
+			return err
+		}
+	}
+	m.readers++
+	return nil
+}
+
+// RUnlock unlocks m for reading.
+func (m *RWMutex) RUnlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.readers == 0 {
+		panic("synchronized: RUnlock of unlocked RWMutex")
+	}
+	m.readers--
+	if m.readers == 0 {
+		m.broadcast()
+	}
+}
+
+// wait waits, with m.mu held, for the next change of the lock or for ctx to
+// be done.
+func (m *RWMutex) wait(ctx context.Context) error {
+	if m.changed == nil {
+		m.changed = make(chan struct{})
+	}
+	changed := m.changed
+	m.mu.Unlock()
+	defer m.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes up, with m.mu held, the callers waiting for the lock.
+func (m *RWMutex) broadcast() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
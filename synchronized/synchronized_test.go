@@ -0,0 +1,98 @@
+package synchronized
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRWMutex(t *testing.T) {
+	var m RWMutex
+	n := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.Lock()
+			defer m.Unlock()
+			n++
+		}()
+		go func() {
+			defer wg.Done()
+			m.RLock()
+			defer m.RUnlock()
+			_ = n
+		}()
+	}
+	wg.Wait()
+	if n != 50 {
+		t.Errorf("n = %d, want 50", n)
+	}
+}
+
+func TestRWMutex_Readers(t *testing.T) {
+	var m RWMutex
+	m.RLock()
+	// The readers share the lock.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.RLockContext(ctx); err != nil {
+		t.Fatalf("RLockContext() = %v, want nil", err)
+	}
+	m.RUnlock()
+	m.RUnlock()
+	if err := m.LockContext(ctx); err != nil {
+		t.Fatalf("LockContext() = %v, want nil", err)
+	}
+	m.Unlock()
+}
+
+func TestRWMutex_Context(t *testing.T) {
+	var m RWMutex
+	m.RLock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.LockContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("LockContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// A writer waiting for the lock excludes the new readers, until it gives
+	// up.
+	ctx, cancel = context.WithCancel(context.Background())
+	locked := make(chan error)
+	go func() { locked <- m.LockContext(ctx) }()
+	for {
+		m.mu.Lock()
+		waiting := m.waiting
+		m.mu.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	rctx, rcancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer rcancel()
+	if err := m.RLockContext(rctx); err != context.DeadlineExceeded {
+		t.Errorf("RLockContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	cancel()
+	if err := <-locked; err != context.Canceled {
+		t.Errorf("LockContext() = %v, want %v", err, context.Canceled)
+	}
+	if err := m.RLockContext(context.Background()); err != nil {
+		t.Errorf("RLockContext() = %v, want nil", err)
+	}
+}
+
+func TestRWMutex_UnlockPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Unlock() of an unlocked RWMutex did not panic")
+		}
+	}()
+	var m RWMutex
+	m.Unlock()
+}
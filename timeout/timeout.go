@@ -0,0 +1,88 @@
+// Package timeout implements the timeouts of the decorators generated by
+// mockgen with -implementation_type=timeout.
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error is the error of a call that did not complete within its timeout. It
+// matches context.DeadlineExceeded, as in
+// errors.Is(err, context.DeadlineExceeded), and the error returned by the
+// call.
+type Error struct {
+	Method  string        // name of the method, as Interface.Method
+	Timeout time.Duration // timeout of the call
+	Err     error         // error returned by the call
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v timed out after %v: %v", e.Method, e.Timeout, e.Err)
+}
+
+// Unwrap returns context.DeadlineExceeded and the error returned by the call.
+func (e *Error) Unwrap() []error {
+	return []error{context.DeadlineExceeded, e.Err}
+}
+
+// callKey is the key of the call carried by the contexts of WithTimeout.
+type callKey struct{}
+
+// call is a call whose context was derived by WithTimeout.
+type call struct {
+	method  string
+	timeout time.Duration
+	own     bool // whether the deadline is the one of the timeout
+}
+
+// WithTimeout returns the context of a call of the method, derived from
+// parent with the given timeout, and its cancel function.
+func WithTimeout(parent context.Context, method string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	c := &call{method: method, timeout: timeout, own: true}
+	if deadline, ok := parent.Deadline(); ok && deadline.Before(time.Now().Add(timeout)) {
+		// The parent expires first, and its errors are not timeouts.
+		c.own = false
+	}
+	return context.WithTimeout(context.WithValue(parent, callKey{}, c), timeout)
+}
+
+// Wrap returns the error err returned by a call with the context ctx of
+// WithTimeout, as an *Error if the timeout of the call expired.
+func Wrap(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	c, ok := ctx.Value(callKey{}).(*call)
+	if !ok || !c.own {
+		return err
+	}
+	return &Error{Method: c.method, Timeout: c.timeout, Err: err}
+}
+
+// NewMethods returns the timeouts of the given methods, by method name. They
+// are the timeout of the method in perMethod, if any, and otherwise def.
+// Methods without a positive timeout are left out. It panics if perMethod
+// holds the timeout of another method.
+func NewMethods(def time.Duration, perMethod map[string]time.Duration, intf string, methods ...string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(methods))
+	known := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		known[m] = true
+		d, ok := perMethod[m]
+		if !ok {
+			d = def
+		}
+		if d > 0 {
+			timeouts[m] = d
+		}
+	}
+	for m := range perMethod {
+		if !known[m] {
+			panic(fmt.Sprintf("timeout: %v.%v is not a method with a context", intf, m))
+		}
+	}
+	return timeouts
+}
@@ -0,0 +1,65 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWrap(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), "Store.Get", time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := Wrap(ctx, ctx.Err())
+	var timeoutErr *Error
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wrap() = %v, want an *Error matching %v", err, context.DeadlineExceeded)
+	}
+	if timeoutErr.Method != "Store.Get" || timeoutErr.Timeout != time.Millisecond {
+		t.Errorf("Wrap() = %+v, want the method and timeout of the call", timeoutErr)
+	}
+
+	if err := Wrap(ctx, nil); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+}
+
+func TestWrap_NotExpired(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), "Store.Get", time.Hour)
+	defer cancel()
+
+	errFailed := errors.New("failed")
+	if err := Wrap(ctx, errFailed); err != errFailed {
+		t.Errorf("Wrap() = %v, want %v", err, errFailed)
+	}
+}
+
+func TestWrap_ParentExpired(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancelParent()
+	ctx, cancel := WithTimeout(parent, "Store.Get", time.Hour)
+	defer cancel()
+	<-ctx.Done()
+
+	if err := Wrap(ctx, ctx.Err()); err != context.DeadlineExceeded {
+		t.Errorf("Wrap() = %v, want the error of the parent context", err)
+	}
+}
+
+func TestNewMethods(t *testing.T) {
+	got := NewMethods(time.Second, map[string]time.Duration{"Put": time.Minute, "Keys": 0}, "Store", "Get", "Put", "Keys")
+	want := map[string]time.Duration{"Get": time.Second, "Put": time.Minute}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMethods() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewMethods() did not panic on the timeout of an unknown method")
+		}
+	}()
+	NewMethods(0, map[string]time.Duration{"Delete": time.Second}, "Store", "Get")
+}
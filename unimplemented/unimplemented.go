@@ -0,0 +1,25 @@
+// Package unimplemented implements the errors of the implementations
+// generated by mockgen with -implementation_type=unimplemented.
+package unimplemented
+
+// ErrNotImplemented is the error returned by the methods of the generated
+// implementations, or the value they panic with when they have no result of
+// type error.
+type ErrNotImplemented struct {
+	Interface string // name of the interface
+	Method    string // name of the method
+}
+
+func (e *ErrNotImplemented) Error() string {
+	return e.Interface + "." + e.Method + " is not implemented"
+}
+
+// Is reports whether target is an *ErrNotImplemented whose fields are empty
+// or equal to the ones of e, so that errors.Is(err, &ErrNotImplemented{})
+// matches the errors of every method.
+func (e *ErrNotImplemented) Is(target error) bool {
+	t, ok := target.(*ErrNotImplemented)
+	return ok &&
+		(t.Interface == "" || t.Interface == e.Interface) &&
+		(t.Method == "" || t.Method == e.Method)
+}
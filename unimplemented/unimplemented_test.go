@@ -0,0 +1,29 @@
+package unimplemented
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrNotImplemented(t *testing.T) {
+	err := fmt.Errorf("get: %w", &ErrNotImplemented{Interface: "Store", Method: "Get"})
+	if got, want := err.Error(), "get: Store.Get is not implemented"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	for _, tc := range []struct {
+		target *ErrNotImplemented
+		want   bool
+	}{
+		{&ErrNotImplemented{}, true},
+		{&ErrNotImplemented{Interface: "Store"}, true},
+		{&ErrNotImplemented{Interface: "Store", Method: "Get"}, true},
+		{&ErrNotImplemented{Interface: "Store", Method: "Put"}, false},
+		{&ErrNotImplemented{Interface: "Config"}, false},
+	} {
+		if got := errors.Is(err, tc.target); got != tc.want {
+			t.Errorf("errors.Is(%v, %+v) = %v, want %v", err, tc.target, got, tc.want)
+		}
+	}
+}